@@ -10,6 +10,9 @@ import (
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/tizz98/magicbell-go/version"
 )
 
@@ -19,19 +22,39 @@ const (
 
 	apiKeyHeader    = "X-MAGICBELL-API-KEY"    // #nosec G101
 	apiSecretHeader = "X-MAGICBELL-API-SECRET" // #nosec G101
+
+	userEmailHeader      = "X-MAGICBELL-USER-EMAIL"
+	userExternalIDHeader = "X-MAGICBELL-USER-EXTERNAL-ID"
 )
 
 // New instantiates a new API which implements the IAPI interface.
 // Config.APIKey and Config.APISecret must be set in order for API requests to succeed.
+// The API is a compatibility layer over the Client returned by NewClient, which should be preferred.
 func New(config Config) IAPI {
+	return newClient(newAPI(config)).api
+}
+
+func newAPI(config Config) *API {
 	if config.BaseURL == "" {
 		config.BaseURL = defaultAPIURL
 	}
 	if config.Timeout == nil {
 		config.Timeout = newDuration(defaultTimeout)
 	}
+	if config.Templates == nil {
+		config.Templates = NewTemplateRegistry()
+	}
+	if config.Metrics == nil {
+		config.Metrics = noopMetrics{}
+	}
 
 	api := &API{config: config}
+	api.tracer, api.propagator = newTracer(config)
+	middleware := append([]Middleware{api.traceOperation, api.measureOperation}, config.Middleware...)
+	if config.CircuitBreaker != nil {
+		middleware = append(middleware, newCircuitBreaker(*config.CircuitBreaker).middleware)
+	}
+	api.handler = chain(api.send, middleware...)
 	api.client = &http.Client{
 		Transport: api,
 		Timeout:   *config.Timeout,
@@ -42,8 +65,12 @@ func New(config Config) IAPI {
 // API implements the IAPI interface for making HTTP requests
 // to the MagicBell API. Use New to instantiate this struct.
 type API struct {
-	config Config
-	client *http.Client
+	config     Config
+	client     *http.Client
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	handler    Handler
+	services   *Client
 }
 
 // Config represents the required values to make HTTP requests to
@@ -59,6 +86,34 @@ type Config struct {
 	// Timeout is an optional time.Duration to wait for HTTP requests to timeout.
 	// If not provided, it will default to 5 seconds.
 	Timeout *time.Duration `yaml:",omitempty"` // optional
+	// SkipValidation disables the client-side validation of requests before they are sent.
+	// By default, requests are validated and ValidationErrors is returned without making an HTTP request.
+	SkipValidation bool `yaml:",omitempty"`
+	// Templates is the registry of NotificationTemplates used by CreateNotificationFromTemplate.
+	// If not provided, an empty registry will be created.
+	Templates *TemplateRegistry `yaml:"-"`
+	// TracerProvider is an optional OpenTelemetry TracerProvider used to create a span for every API request.
+	// If not provided, requests will not be traced.
+	TracerProvider trace.TracerProvider `yaml:"-"`
+	// Propagator is an optional OpenTelemetry propagator used to add trace context headers to API requests.
+	// If not provided and TracerProvider is set, it will default to the global propagator.
+	Propagator propagation.TextMapPropagator `yaml:"-"`
+	// Metrics is an optional Metrics implementation which receives measurements for every API request.
+	// If not provided, no measurements are recorded.
+	Metrics Metrics `yaml:"-"`
+	// Logger is an optional Logger used to log every HTTP request and response at debug level.
+	// Secret headers, such as the API secret, are always redacted. If not provided, nothing is logged.
+	Logger Logger `yaml:"-"`
+	// LogBodies enables logging of the request and response bodies when Logger is set.
+	// Bodies may contain personal information about your users, so this is disabled by default.
+	LogBodies bool `yaml:",omitempty"`
+	// Middleware wraps every API request, the first Middleware being the outermost.
+	// Tracing and Metrics always wrap the Middleware, so they measure each IAPI call as a whole.
+	Middleware []Middleware `yaml:"-"`
+	// CircuitBreaker enables a circuit breaker per operation which fails requests fast with a CircuitOpenError
+	// while the API is failing. It wraps each HTTP request after the Middleware, so every retry is counted.
+	// If not provided, no circuit breaker is used.
+	CircuitBreaker *CircuitBreakerSettings `yaml:"-"`
 }
 
 func (c *Config) withBaseURL(url string) Config {
@@ -70,20 +125,45 @@ func (c *Config) withBaseURL(url string) Config {
 // RoundTrip implements the http.RoundTripper interface and is used for the API's http.Client http.Transport.
 // This method will automatically add the Config.APIKey and Config.APISecret as headers for all HTTP requests.
 // After doing so, the http.DefaultTransport will be used to finish making the request.
+// When Config.Logger is set, the request and response are logged with secret headers redacted.
 func (a *API) RoundTrip(r *http.Request) (*http.Response, error) {
 	r.Header.Set(apiKeyHeader, a.config.APIKey)
 	r.Header.Set(apiSecretHeader, a.config.APISecret)
 	r.Header.Set("User-Agent", fmt.Sprintf("%s/%s", version.BuildName, version.BuildVersion))
 	r.Header.Set("Accept", "application/json")
 	r.Header.Set("Content-Type", "application/json")
-	return http.DefaultTransport.RoundTrip(r)
+
+	if a.config.Logger == nil {
+		return http.DefaultTransport.RoundTrip(r)
+	}
+
+	a.logRequest(r)
+	start := time.Now()
+	resp, err := http.DefaultTransport.RoundTrip(r)
+	a.logResponse(r, resp, time.Since(start), err)
+	return resp, err
 }
 
-func (a *API) makeRequest(ctx context.Context, method string, endpoint string, requestBody interface{}, out interface{}) error {
+// makeRequest sends the request body to the endpoint and decodes the response into out.
+// The operation is the name of the IAPI method making the request, for example CreateNotification.
+func (a *API) makeRequest(ctx context.Context, operation string, method string, endpoint string, requestBody interface{}, out interface{}, opts ...CallOption) error {
+	return newCallOptions(opts).do(ctx, a, &Operation{
+		Name:     operation,
+		Method:   method,
+		Endpoint: endpoint,
+		Header:   http.Header{},
+		Request:  requestBody,
+		Response: out,
+	})
+}
+
+// send is the innermost Handler of the middleware chain, it makes the HTTP request for the Operation.
+func (a *API) send(ctx context.Context, op *Operation) error {
 	var bodyReader io.Reader
+	op.StatusCode = 0
 
-	if requestBody != nil {
-		serializedBody, err := json.Marshal(requestBody)
+	if op.Request != nil {
+		serializedBody, err := json.Marshal(op.Request)
 		if err != nil {
 			return fmt.Errorf("magicbell-go/api: error serializing request body: %w", err)
 		}
@@ -91,16 +171,20 @@ func (a *API) makeRequest(ctx context.Context, method string, endpoint string, r
 		bodyReader = bytes.NewReader(serializedBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", a.config.BaseURL, endpoint), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, op.Method, a.operationURL(op), bodyReader)
 	if err != nil {
 		return fmt.Errorf("magicbell-go/api: error creating http request: %w", err)
 	}
+	for key, values := range op.Header {
+		req.Header[key] = values
+	}
 
 	resp, err := a.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("magicbell-go/api: error making http request: %w", err)
 	}
 	defer resp.Body.Close()
+	op.StatusCode = resp.StatusCode
 
 	if resp.StatusCode >= 500 {
 		var bodyStr string
@@ -117,10 +201,22 @@ func (a *API) makeRequest(ctx context.Context, method string, endpoint string, r
 		return nil
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+	// some endpoints respond with an empty body on success
+	if err := json.NewDecoder(resp.Body).Decode(op.Response); err != nil && err != io.EOF {
 		return fmt.Errorf("magicbell-go/api: error decoding response json: %w", err)
 	}
 	return nil
 }
 
+func (a *API) operationURL(op *Operation) string {
+	return fmt.Sprintf("%s/%s", a.config.BaseURL, op.Endpoint)
+}
+
+func (a *API) validate(v interface{ Validate() error }) error {
+	if a.config.SkipValidation {
+		return nil
+	}
+	return v.Validate()
+}
+
 func newDuration(d time.Duration) *time.Duration { return &d }
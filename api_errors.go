@@ -97,3 +97,50 @@ func IsInternalServerError(err error) bool {
 	_, ok := err.(InternalServerError)
 	return ok
 }
+
+// NotFoundError represents a 404 HTTP error returned from the API,
+// for example when getting a user which does not exist.
+type NotFoundError struct {
+	// Errors are the errors returned in the response body, if any
+	Errors APIErrors
+}
+
+// Error returns a simple string describing the HTTP error, along with the first APIError if any
+func (e NotFoundError) Error() string {
+	msg := fmt.Sprintf("HTTP %d %s", http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	if len(e.Errors) > 0 {
+		msg += ": " + e.Errors.Error()
+	}
+	return msg
+}
+
+// IsNotFoundError returns true when the underlying error is a NotFoundError
+func IsNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(NotFoundError)
+	return ok
+}
+
+// errorCodes returns the APIErrorCodes contained in err, if any.
+func errorCodes(err error) []string {
+	var codes []string
+
+	switch e := err.(type) {
+	case NotFoundError:
+		return errorCodes(e.Errors)
+	case APIErrors:
+		for _, apiErr := range e {
+			if apiErr.Code != "" {
+				codes = append(codes, string(apiErr.Code))
+			}
+		}
+	case APIError:
+		if e.Code != "" {
+			codes = append(codes, string(e.Code))
+		}
+	}
+
+	return codes
+}
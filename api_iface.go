@@ -2,7 +2,8 @@ package magicbell
 
 import "context"
 
-// IAPI contains all the methods for working with the MagicBell API
+// IAPI contains all the methods for working with the MagicBell API.
+// It is kept for compatibility, new code should use the Client returned by NewClient.
 type IAPI interface {
 	// GenerateUserEmailHMAC generates a sha256 HMAC signature of the user's email
 	// using the APISecret as the HMAC key. The returned value is a base64 encoded
@@ -12,6 +13,20 @@ type IAPI interface {
 	CreateNotification(req CreateNotificationRequest) (*BaseNotification, error)
 	// CreateNotificationC sends a notification to one or multiple users, using a context.Context in the HTTP request.
 	CreateNotificationC(ctx context.Context, req CreateNotificationRequest) (*BaseNotification, error)
+	// CreateNotificationBulk sends the same notification to any number of recipients, using a context.Context
+	// in the HTTP requests. Duplicate recipients are removed and the rest are split into chunks which are sent
+	// concurrently as separate notifications. An error is only returned when the request is invalid, the outcome
+	// of each chunk is reported in the returned BulkNotificationResult.
+	CreateNotificationBulk(ctx context.Context, req CreateNotificationRequest, opts BulkOptions) (*BulkNotificationResult, error)
+	// RegisterTemplate parses the NotificationTemplate and stores it under name
+	// for use with CreateNotificationFromTemplate.
+	RegisterTemplate(name string, tmpl NotificationTemplate) error
+	// CreateNotificationFromTemplate renders the template registered under name with data and
+	// sends the resulting notification to the recipients, using a context.Context in the HTTP request.
+	// The data is also attached to the notification as its CustomAttributes.
+	CreateNotificationFromTemplate(ctx context.Context, name string, recipients []NotificationRecipient, data CustomAttributes) (*BaseNotification, error)
+	// The user-scoped notification methods are only available on Client.Notifications for now,
+	// for example Client.Notifications.List and Client.Notifications.MarkRead.
 	//FetchUserNotifications()
 	//FetchUserNotificationsC(ctx context.Context)
 	//FetchUserNotification()
@@ -0,0 +1,182 @@
+package magicbell
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// MaxRecipientsPerNotification is the maximum number of recipients
+	// the MagicBell API accepts when creating a single notification.
+	MaxRecipientsPerNotification = 1000
+
+	defaultBulkConcurrency = 4
+)
+
+// BulkOptions configures how CreateNotificationBulk splits and sends recipients.
+type BulkOptions struct {
+	// ChunkSize is the number of recipients to send per notification. If not provided,
+	// or larger than MaxRecipientsPerNotification, it will default to MaxRecipientsPerNotification.
+	ChunkSize int
+	// Concurrency is the maximum number of notifications to send in parallel.
+	// If not provided, it will default to 4.
+	Concurrency int
+}
+
+// BulkNotificationChunk is the outcome of sending a notification to a single chunk of recipients.
+// Exactly one of Notification and Err is set.
+type BulkNotificationChunk struct {
+	// Index is the position of this chunk in BulkNotificationResult.Chunks
+	Index int
+	// Recipients are the recipients this chunk was sent to
+	Recipients []NotificationRecipient
+	// Notification is the notification created for this chunk, if sending succeeded
+	Notification *BaseNotification
+	// Err is the error returned when sending this chunk, if sending failed
+	Err error
+}
+
+// BulkNotificationResult is the aggregated outcome of CreateNotificationBulk.
+type BulkNotificationResult struct {
+	// Chunks are the outcome of every chunk of recipients, in the order of the original recipients
+	Chunks []BulkNotificationChunk
+	// DuplicateRecipients is the number of recipients that were removed because they were duplicates
+	DuplicateRecipients int
+}
+
+// Failed returns the chunks which could not be sent.
+func (r BulkNotificationResult) Failed() []BulkNotificationChunk {
+	var failed []BulkNotificationChunk
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			failed = append(failed, chunk)
+		}
+	}
+	return failed
+}
+
+// NotificationIDs returns the IDs of the notifications created for the chunks which were sent successfully.
+func (r BulkNotificationResult) NotificationIDs() []string {
+	var ids []string
+	for _, chunk := range r.Chunks {
+		if chunk.Notification != nil {
+			ids = append(ids, chunk.Notification.ID)
+		}
+	}
+	return ids
+}
+
+// CreateNotificationBulk sends the same notification to any number of recipients, using a context.Context
+// in the HTTP requests. Duplicate recipients are removed and the rest are split into chunks which are sent
+// concurrently as separate notifications. An error is only returned when the request is invalid, the outcome
+// of each chunk is reported in the returned BulkNotificationResult.
+func (a *API) CreateNotificationBulk(ctx context.Context, req CreateNotificationRequest, opts BulkOptions) (*BulkNotificationResult, error) {
+	return a.services.Notifications.CreateBulk(ctx, req, opts)
+}
+
+// CreateBulk sends the same notification to any number of recipients. Duplicate recipients are removed
+// and the rest are split into chunks which are sent concurrently as separate notifications.
+// An error is only returned when the request is invalid, the outcome of each chunk is reported
+// in the returned BulkNotificationResult. When WithIdempotencyKey is given, the index of each
+// chunk is appended to the key, so every chunk is sent with a different key.
+func (s *NotificationsService) CreateBulk(ctx context.Context, req CreateNotificationRequest, opts BulkOptions, callOpts ...CallOption) (*BulkNotificationResult, error) {
+	recipients, duplicates := dedupeRecipients(req.Recipients)
+	req.Recipients = recipients
+
+	if err := s.api.validate(req); err != nil {
+		return nil, err
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 || chunkSize > MaxRecipientsPerNotification {
+		chunkSize = MaxRecipientsPerNotification
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	result := &BulkNotificationResult{DuplicateRecipients: duplicates}
+	for start := 0; start < len(recipients); start += chunkSize {
+		end := start + chunkSize
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+
+		result.Chunks = append(result.Chunks, BulkNotificationChunk{
+			Index:      len(result.Chunks),
+			Recipients: recipients[start:end],
+		})
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i := range result.Chunks {
+		chunk := &result.Chunks[i]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			chunk.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			chunkReq := req
+			chunkReq.Recipients = chunk.Recipients
+			chunk.Notification, chunk.Err = s.Create(ctx, chunkReq, chunkCallOptions(callOpts, chunk.Index)...)
+		}()
+	}
+
+	wg.Wait()
+	return result, nil
+}
+
+// CreateNotificationBulk is a global shortcut to API.CreateNotificationBulk.
+// The project named on ctx with WithProject is used, if any.
+func CreateNotificationBulk(ctx context.Context, req CreateNotificationRequest, opts BulkOptions) (*BulkNotificationResult, error) {
+	projectAPI, err := apiFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return projectAPI.CreateNotificationBulk(ctx, req, opts)
+}
+
+// chunkCallOptions derives the idempotency key of the chunk at index from the one in opts, if any.
+func chunkCallOptions(opts []CallOption, index int) []CallOption {
+	key := newCallOptions(opts).header.Get(idempotencyKeyHeader)
+	if key == "" {
+		return opts
+	}
+
+	chunkOpts := make([]CallOption, 0, len(opts)+1)
+	chunkOpts = append(chunkOpts, opts...)
+	return append(chunkOpts, WithIdempotencyKey(fmt.Sprintf("%s-%d", key, index)))
+}
+
+// dedupeRecipients returns the recipients without duplicates, keeping the first occurrence,
+// and the number of duplicates removed. Recipients without an email or external id are kept as-is.
+func dedupeRecipients(recipients []NotificationRecipient) ([]NotificationRecipient, int) {
+	seen := make(map[string]bool, len(recipients))
+	deduped := make([]NotificationRecipient, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		if key := recipient.identity(); key != "" {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		deduped = append(deduped, recipient)
+	}
+
+	return deduped, len(recipients) - len(deduped)
+}
@@ -0,0 +1,77 @@
+package magicbell
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkRecipients(n int) []NotificationRecipient {
+	recipients := make([]NotificationRecipient, n)
+	for i := range recipients {
+		recipients[i] = NotificationRecipient{Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	return recipients
+}
+
+func TestAPI_CreateNotificationBulk(t *testing.T) {
+	request := initialCreateNotificationRequest
+	request.Recipients = append(bulkRecipients(2500), NotificationRecipient{Email: "USER0@example.com"}, NotificationRecipient{ExternalID: "1924"})
+
+	runServer(t, "/notifications", http.MethodPost, http.StatusCreated, func(config Config) {
+		api := New(config)
+
+		result, err := api.CreateNotificationBulk(context.Background(), request, BulkOptions{Concurrency: 2})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, 1, result.DuplicateRecipients)
+		require.Len(t, result.Chunks, 3)
+		for i, size := range []int{1000, 1000, 501} {
+			assert.Equal(t, i, result.Chunks[i].Index)
+			assert.Len(t, result.Chunks[i].Recipients, size)
+			assert.NoError(t, result.Chunks[i].Err)
+		}
+		assert.Equal(t, NotificationRecipient{ExternalID: "1924"}, result.Chunks[2].Recipients[500])
+		assert.Empty(t, result.Failed())
+		assert.Equal(t, []string{
+			"ffffff66-ea4f-4da2-afc6-84148b51657a",
+			"ffffff66-ea4f-4da2-afc6-84148b51657a",
+			"ffffff66-ea4f-4da2-afc6-84148b51657a",
+		}, result.NotificationIDs())
+	})
+}
+
+func TestAPI_CreateNotificationBulk_ChunkErrors(t *testing.T) {
+	request := initialCreateNotificationRequest
+	request.Recipients = bulkRecipients(5)
+
+	runServer(t, "/notifications", http.MethodPost, http.StatusInternalServerError, func(config Config) {
+		api := New(config)
+
+		result, err := api.CreateNotificationBulk(context.Background(), request, BulkOptions{ChunkSize: 2})
+		require.NoError(t, err)
+		require.Len(t, result.Chunks, 3)
+		assert.Len(t, result.Failed(), 3)
+		assert.Empty(t, result.NotificationIDs())
+		for _, chunk := range result.Chunks {
+			assertInternalServerError(t, chunk.Err)
+			assert.Nil(t, chunk.Notification)
+		}
+	})
+}
+
+func TestCreateNotificationBulk(t *testing.T) {
+	request := initialCreateNotificationRequest
+	request.Title = ""
+
+	runGlobalTest(validConfig, func() {
+		result, err := CreateNotificationBulk(context.Background(), request, BulkOptions{})
+		assert.True(t, IsValidationErrors(err))
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,264 @@
+package magicbell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of the circuit breaker for a single operation.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through, this is the initial state.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests fast with a CircuitOpenError.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial requests through to check if the API has recovered.
+	CircuitHalfOpen
+)
+
+// String returns the name of the CircuitState
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerSettings configures the circuit breaker enabled with Config.CircuitBreaker.
+// Every operation, for example CreateNotification, has its own circuit. Requests sent with Client.Do
+// have a circuit per method and endpoint, for example "Do GET users/{id}", ids in the path are
+// replaced with {id} and the query is ignored.
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of failures within Window which opens the circuit.
+	// If not provided, it will default to 5.
+	FailureThreshold int
+	// Window is the rolling window failures are counted in. If not provided, it will default to 1 minute.
+	Window time.Duration
+	// OpenTimeout is how long the circuit stays open before letting trial requests through.
+	// If not provided, it will default to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of concurrent trial requests let through while half-open.
+	// If not provided, it will default to 1.
+	HalfOpenMaxRequests int
+	// IsFailure reports whether the error counts as a failure. If not provided, 5xx responses
+	// and network errors are failures, errors returned by the API such as invalid params are not.
+	IsFailure func(err error) bool
+	// OnStateChange is called whenever the circuit of an operation changes state, this is optional.
+	OnStateChange func(operation string, from CircuitState, to CircuitState)
+}
+
+// CircuitOpenError is returned without making an HTTP request when the circuit of the operation is open.
+type CircuitOpenError struct {
+	// Operation is the name of the IAPI method whose circuit is open, for example CreateNotification,
+	// or the method and endpoint of a Client.Do request, for example "Do GET users/{id}".
+	Operation string
+	// OpenUntil is when trial requests will be let through again.
+	OpenUntil time.Time
+}
+
+// Error returns a simple string describing the open circuit
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("magicbell-go/api: circuit breaker is open for %s", e.Operation)
+}
+
+// IsCircuitOpenError returns true when the underlying error is a CircuitOpenError
+func IsCircuitOpenError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var circuitErr CircuitOpenError
+	return errors.As(err, &circuitErr)
+}
+
+type circuit struct {
+	state            CircuitState
+	failures         []time.Time
+	openedAt         time.Time
+	halfOpenInFlight int
+}
+
+type stateChange struct {
+	operation string
+	from, to  CircuitState
+}
+
+type circuitBreaker struct {
+	settings CircuitBreakerSettings
+	now      func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newCircuitBreaker(settings CircuitBreakerSettings) *circuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.Window <= 0 {
+		settings.Window = time.Minute
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+	if settings.HalfOpenMaxRequests <= 0 {
+		settings.HalfOpenMaxRequests = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = isRetryable
+	}
+
+	return &circuitBreaker{
+		settings: settings,
+		now:      time.Now,
+		circuits: map[string]*circuit{},
+	}
+}
+
+// middleware fails the Operation fast when its circuit is open, and records its outcome otherwise.
+func (b *circuitBreaker) middleware(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) error {
+		key := circuitKey(op)
+		trial, err := b.allow(key)
+		if err != nil {
+			return err
+		}
+
+		err = next(ctx, op)
+		b.record(key, trial, err != nil && b.settings.IsFailure(err))
+		return err
+	}
+}
+
+// circuitKey returns the name of the circuit of the Operation. Client.Do requests share the Do name,
+// so they are told apart by method and endpoint, with ids replaced to keep the number of circuits bounded.
+func circuitKey(op *Operation) string {
+	if op.Name != doOperation {
+		return op.Name
+	}
+
+	endpoint := op.Endpoint
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "0123456789@:") {
+			segments[i] = "{id}"
+		}
+	}
+	return op.Name + " " + op.Method + " " + strings.Join(segments, "/")
+}
+
+// allow returns a CircuitOpenError if the operation may not be performed,
+// and whether the operation is a trial request of a half-open circuit.
+func (b *circuitBreaker) allow(operation string) (bool, error) {
+	b.mu.Lock()
+	c := b.circuit(operation)
+	now := b.now()
+
+	var changes []stateChange
+	if c.state == CircuitOpen && !now.Before(c.openedAt.Add(b.settings.OpenTimeout)) {
+		changes = append(changes, b.transition(operation, c, CircuitHalfOpen, now))
+	}
+
+	var trial bool
+	var err error
+	switch {
+	case c.state == CircuitOpen:
+		err = CircuitOpenError{Operation: operation, OpenUntil: c.openedAt.Add(b.settings.OpenTimeout)}
+	case c.state == CircuitHalfOpen && c.halfOpenInFlight >= b.settings.HalfOpenMaxRequests:
+		err = CircuitOpenError{Operation: operation, OpenUntil: now}
+	case c.state == CircuitHalfOpen:
+		c.halfOpenInFlight++
+		trial = true
+	}
+	b.mu.Unlock()
+
+	b.notify(changes)
+	return trial, err
+}
+
+// record updates the circuit of the operation with the outcome of a request.
+func (b *circuitBreaker) record(operation string, trial bool, failed bool) {
+	b.mu.Lock()
+	c := b.circuit(operation)
+	now := b.now()
+
+	var changes []stateChange
+	if trial {
+		c.halfOpenInFlight--
+	}
+
+	switch c.state {
+	case CircuitHalfOpen:
+		// only the trial requests tell if the API has recovered, the requests started
+		// before the circuit opened may still be failing
+		if !trial {
+			break
+		}
+		if failed {
+			changes = append(changes, b.transition(operation, c, CircuitOpen, now))
+		} else {
+			changes = append(changes, b.transition(operation, c, CircuitClosed, now))
+		}
+	case CircuitClosed:
+		if !failed {
+			break
+		}
+
+		c.failures = append(c.failures, now)
+		cutoff := now.Add(-b.settings.Window)
+		for len(c.failures) > 0 && !c.failures[0].After(cutoff) {
+			c.failures = c.failures[1:]
+		}
+		if len(c.failures) >= b.settings.FailureThreshold {
+			changes = append(changes, b.transition(operation, c, CircuitOpen, now))
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(changes)
+}
+
+func (b *circuitBreaker) circuit(operation string) *circuit {
+	c, ok := b.circuits[operation]
+	if !ok {
+		c = &circuit{}
+		b.circuits[operation] = c
+	}
+	return c
+}
+
+// transition must be called with b.mu held.
+func (b *circuitBreaker) transition(operation string, c *circuit, to CircuitState, now time.Time) stateChange {
+	change := stateChange{operation: operation, from: c.state, to: to}
+
+	c.state = to
+	c.failures = nil
+	if to == CircuitOpen {
+		c.openedAt = now
+	}
+
+	return change
+}
+
+// notify calls OnStateChange for every change, it must be called without b.mu held.
+func (b *circuitBreaker) notify(changes []stateChange) {
+	if b.settings.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		b.settings.OnStateChange(change.operation, change.from, change.to)
+	}
+}
@@ -0,0 +1,213 @@
+package magicbell
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2021, 2, 9, 0, 0, 0, 0, time.UTC)}
+
+	var changes []string
+	breaker := newCircuitBreaker(CircuitBreakerSettings{
+		FailureThreshold: 2,
+		Window:           10 * time.Second,
+		OpenTimeout:      30 * time.Second,
+		OnStateChange: func(operation string, from CircuitState, to CircuitState) {
+			changes = append(changes, operation+": "+from.String()+" -> "+to.String())
+		},
+	})
+	breaker.now = clock.Now
+
+	var responseErr error
+	var calls int
+	handler := breaker.middleware(func(ctx context.Context, op *Operation) error {
+		calls++
+		return responseErr
+	})
+	call := func(operation string) error {
+		return handler(context.Background(), &Operation{Name: operation})
+	}
+
+	// failures outside of the rolling window do not open the circuit
+	responseErr = InternalServerError{StatusCode: 503}
+	assertInternalServerError503(t, call("CreateNotification"))
+	clock.Advance(11 * time.Second)
+	assertInternalServerError503(t, call("CreateNotification"))
+	assert.Empty(t, changes)
+
+	// errors returned by the API are not failures
+	responseErr = APIErrors{{Code: APIErrorCodeForbidden}}
+	assert.Equal(t, responseErr, call("CreateNotification"))
+	assert.Empty(t, changes)
+
+	responseErr = InternalServerError{StatusCode: 503}
+	assertInternalServerError503(t, call("CreateNotification"))
+	assert.Equal(t, []string{"CreateNotification: closed -> open"}, changes)
+
+	// open circuits fail fast, other operations are unaffected
+	calls = 0
+	err := call("CreateNotification")
+	require.True(t, IsCircuitOpenError(err))
+	assert.Equal(t, CircuitOpenError{Operation: "CreateNotification", OpenUntil: clock.now.Add(30 * time.Second)}, err)
+	assert.Equal(t, "magicbell-go/api: circuit breaker is open for CreateNotification", err.Error())
+	assertInternalServerError503(t, call("CreateUser"))
+	assert.Equal(t, 1, calls)
+
+	// a failed trial request opens the circuit again
+	clock.Advance(30 * time.Second)
+	assertInternalServerError503(t, call("CreateNotification"))
+	assert.True(t, IsCircuitOpenError(call("CreateNotification")))
+
+	// a successful trial request closes the circuit
+	clock.Advance(30 * time.Second)
+	responseErr = nil
+	assert.NoError(t, call("CreateNotification"))
+	assert.NoError(t, call("CreateNotification"))
+
+	assert.Equal(t, []string{
+		"CreateNotification: closed -> open",
+		"CreateNotification: open -> half-open",
+		"CreateNotification: half-open -> open",
+		"CreateNotification: open -> half-open",
+		"CreateNotification: half-open -> closed",
+	}, changes)
+}
+
+func TestCircuitBreaker_HalfOpenMaxRequests(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	breaker := newCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})
+	breaker.now = clock.Now
+
+	_, _ = breaker.allow("CreateUser")
+	breaker.record("CreateUser", false, true)
+	clock.Advance(time.Minute)
+
+	trial, err := breaker.allow("CreateUser")
+	require.NoError(t, err)
+	assert.True(t, trial)
+
+	_, err = breaker.allow("CreateUser")
+	assert.True(t, IsCircuitOpenError(err))
+}
+
+func TestCircuitKey(t *testing.T) {
+	tests := []struct {
+		name string
+		op   Operation
+		want string
+	}{
+		{name: "named operation", op: Operation{Name: "CreateUser", Method: "POST", Endpoint: "users"}, want: "CreateUser"},
+		{name: "do", op: Operation{Name: "Do", Method: "GET", Endpoint: "users"}, want: "Do GET users"},
+		{name: "do with query", op: Operation{Name: "Do", Method: "GET", Endpoint: "users?per_page=1"}, want: "Do GET users"},
+		{
+			name: "do with uuid",
+			op:   Operation{Name: "Do", Method: "PUT", Endpoint: "users/7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c"},
+			want: "Do PUT users/{id}",
+		},
+		{
+			name: "do with email",
+			op:   Operation{Name: "Do", Method: "DELETE", Endpoint: "users/email:hana@magicbell.io"},
+			want: "Do DELETE users/{id}",
+		},
+		{
+			name: "do with nested id",
+			op:   Operation{Name: "Do", Method: "POST", Endpoint: "/notifications/1234/read"},
+			want: "Do POST notifications/{id}/read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, circuitKey(&tt.op))
+		})
+	}
+}
+
+func TestCircuitBreaker_DoEndpoints(t *testing.T) {
+	breaker := newCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})
+	handler := breaker.middleware(func(ctx context.Context, op *Operation) error {
+		if op.Endpoint == "broken" {
+			return InternalServerError{StatusCode: 503}
+		}
+		return nil
+	})
+	call := func(endpoint string) error {
+		return handler(context.Background(), &Operation{Name: "Do", Method: "GET", Endpoint: endpoint})
+	}
+
+	assertInternalServerError503(t, call("broken"))
+	assert.True(t, IsCircuitOpenError(call("broken")))
+	assert.NoError(t, call("users"))
+}
+
+func TestAPI_CircuitBreaker(t *testing.T) {
+	runSequenceServer(t, []int{500}, func(config Config, requests *int32) {
+		var opened int32
+		config.CircuitBreaker = &CircuitBreakerSettings{
+			FailureThreshold: 2,
+			OnStateChange: func(operation string, from CircuitState, to CircuitState) {
+				if to == CircuitOpen {
+					atomic.AddInt32(&opened, 1)
+				}
+			},
+		}
+		api := New(config)
+
+		for i := 0; i < 2; i++ {
+			_, err := api.CreateNotification(initialCreateNotificationRequest)
+			assertInternalServerError(t, err)
+		}
+
+		notification, err := api.CreateNotification(initialCreateNotificationRequest)
+		assert.Nil(t, notification)
+		assert.True(t, IsCircuitOpenError(err))
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&opened))
+	})
+}
+
+func assertInternalServerError503(t *testing.T, err error) {
+	var serverErr InternalServerError
+	require.True(t, errors.As(err, &serverErr))
+	assert.Equal(t, 503, serverErr.StatusCode)
+}
+
+func TestCircuitBreaker_HalfOpenIgnoresRequestsStartedBeforeOpen(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	breaker := newCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})
+	breaker.now = clock.Now
+
+	// a slow request started while the circuit was closed
+	slow, err := breaker.allow("CreateUser")
+	require.NoError(t, err)
+	assert.False(t, slow)
+
+	_, _ = breaker.allow("CreateUser")
+	breaker.record("CreateUser", false, true)
+	clock.Advance(time.Minute)
+
+	trial, err := breaker.allow("CreateUser")
+	require.NoError(t, err)
+	assert.True(t, trial)
+
+	// the slow request failing does not reopen the circuit
+	breaker.record("CreateUser", slow, true)
+	assert.Equal(t, CircuitHalfOpen, breaker.circuit("CreateUser").state)
+
+	breaker.record("CreateUser", trial, false)
+	assert.Equal(t, CircuitClosed, breaker.circuit("CreateUser").state)
+}
@@ -0,0 +1,219 @@
+package magicbell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// Client is a client for the MagicBell API with operations grouped by resource.
+// Every operation accepts a context.Context and optional CallOptions. Use NewClient to instantiate this struct.
+type Client struct {
+	// Users are the operations on MagicBell users
+	Users *UsersService
+	// Notifications are the operations on MagicBell notifications
+	Notifications *NotificationsService
+	// Templates is the registry of NotificationTemplates used by NotificationsService.CreateFromTemplate
+	Templates *TemplateRegistry
+
+	api *API
+}
+
+// Option configures the Client created by NewClient.
+type Option func(*Config)
+
+// NewClient instantiates a new Client configured with opts.
+// WithAPIKey and WithAPISecret, or WithConfig, must be given in order for API requests to succeed.
+func NewClient(opts ...Option) *Client {
+	var config Config
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return newClient(newAPI(config))
+}
+
+func newClient(a *API) *Client {
+	c := &Client{
+		Users:         &UsersService{api: a},
+		Notifications: &NotificationsService{api: a},
+		Templates:     a.config.Templates,
+		api:           a,
+	}
+	a.services = c
+	return c
+}
+
+// API returns the IAPI using the same configuration as the Client,
+// for code which has not moved to the Client yet.
+func (c *Client) API() IAPI { return c.api }
+
+// VerifyCredentials checks the configured API key and secret with a request which changes nothing.
+// It returns ValidationErrors when they are missing, and the APIErrors returned by MagicBell when they are wrong,
+// such as APIErrorCodeIncorrectAPIKey or APIErrorCodeAPISecretIsIncorrect. A NotFoundError means the
+// BaseURL is not the MagicBell API.
+func (c *Client) VerifyCredentials(ctx context.Context, opts ...CallOption) error {
+	if err := c.api.config.Validate(); err != nil {
+		return err
+	}
+	return c.Do(ctx, http.MethodGet, "users?per_page=1", nil, nil, opts...)
+}
+
+// doOperation is the Operation name of requests sent with Client.Do.
+const doOperation = "Do"
+
+// Do sends a request to an endpoint of the API which has no operation on the Client yet, such as
+// "users?per_page=10", through the same middleware and with the same headers as the other operations.
+// The body is serialized to JSON unless nil, and the JSON response body is decoded into out unless nil,
+// out may be a *json.RawMessage. The errors returned by the API are returned as APIErrors, NotFoundError
+// or InternalServerError, in which case out still receives the JSON response body of 4xx responses.
+func (c *Client) Do(ctx context.Context, method, endpoint string, body, out interface{}, opts ...CallOption) error {
+	var raw json.RawMessage
+	op := &Operation{
+		Name:     doOperation,
+		Method:   method,
+		Endpoint: strings.TrimPrefix(endpoint, "/"),
+		Header:   http.Header{},
+		Request:  body,
+		Response: &raw,
+	}
+	// the body of a 404 may not be JSON, e.g. when the endpoint does not exist
+	if err := newCallOptions(opts).do(ctx, c.api, op); err != nil && op.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	if out != nil && len(raw) > 0 {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return fmt.Errorf("magicbell-go/api: error decoding response json: %w", err)
+		}
+	}
+
+	if op.StatusCode >= http.StatusBadRequest {
+		var errs baseResponse
+		_ = json.Unmarshal(raw, &errs)
+		if op.StatusCode == http.StatusNotFound {
+			return NotFoundError{Errors: errs.Errors}
+		}
+		if errs.Err() != nil {
+			return errs.Err()
+		}
+		return fmt.Errorf("magicbell-go/api: HTTP %d %s", op.StatusCode, http.StatusText(op.StatusCode))
+	}
+	return nil
+}
+
+// WithConfig uses config as the Client's configuration, replacing any Option given before it.
+// This is useful with ConfigFromEnv and LoadConfig.
+func WithConfig(config Config) Option {
+	return func(c *Config) { *c = config }
+}
+
+// WithAPIKey sets the api key for your MagicBell account.
+func WithAPIKey(key string) Option {
+	return func(c *Config) { c.APIKey = key }
+}
+
+// WithAPISecret sets the api secret for your MagicBell account.
+func WithAPISecret(secret string) Option {
+	return func(c *Config) { c.APISecret = secret }
+}
+
+// WithBaseURL sets the MagicBell API url, it defaults to https://api.magicbell.io
+func WithBaseURL(url string) Option {
+	return func(c *Config) { c.BaseURL = url }
+}
+
+// WithTimeout sets how long to wait for each HTTP request to timeout, it defaults to 5 seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Config) { c.Timeout = newDuration(d) }
+}
+
+// WithoutValidation disables the client-side validation of requests before they are sent.
+func WithoutValidation() Option {
+	return func(c *Config) { c.SkipValidation = true }
+}
+
+// WithTemplates sets the registry of NotificationTemplates used by NotificationsService.CreateFromTemplate.
+func WithTemplates(templates *TemplateRegistry) Option {
+	return func(c *Config) { c.Templates = templates }
+}
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider used to create a span for every API request.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Config) { c.TracerProvider = provider }
+}
+
+// WithMetrics sets the Metrics implementation which receives measurements for every API request.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Config) { c.Metrics = metrics }
+}
+
+// WithLogger sets the Logger used to log every HTTP request and response at debug level.
+// See Config.LogBodies to include the bodies as well.
+func WithLogger(logger Logger) Option {
+	return func(c *Config) { c.Logger = logger }
+}
+
+// WithMiddleware appends middleware to wrap every API request, the first Middleware being the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Config) { c.Middleware = append(c.Middleware, middleware...) }
+}
+
+// WithCircuitBreaker enables a circuit breaker per operation, see Config.CircuitBreaker.
+func WithCircuitBreaker(settings CircuitBreakerSettings) Option {
+	return func(c *Config) { c.CircuitBreaker = &settings }
+}
+
+// CallOption configures a single operation of the Client.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout time.Duration
+	header  http.Header
+}
+
+func newCallOptions(opts []CallOption) callOptions {
+	call := callOptions{header: http.Header{}}
+	for _, opt := range opts {
+		opt(&call)
+	}
+	return call
+}
+
+// WithCallTimeout limits how long the whole operation may take, including any retries made by Middleware.
+// Config.Timeout still limits each HTTP request.
+func WithCallTimeout(d time.Duration) CallOption {
+	return func(c *callOptions) { c.timeout = d }
+}
+
+// WithIdempotencyKey sends key as the Idempotency-Key header, so the operation is only performed once
+// by MagicBell when it is retried.
+func WithIdempotencyKey(key string) CallOption {
+	return WithHeader(idempotencyKeyHeader, key)
+}
+
+// WithHeader sets an additional HTTP header on the operation's requests.
+// The headers set by the Client, such as the API key, cannot be overridden.
+func WithHeader(key, value string) CallOption {
+	return func(c *callOptions) { c.header.Set(key, value) }
+}
+
+// do runs the operation through the middleware chain with the call options applied.
+func (c callOptions) do(ctx context.Context, a *API, op *Operation) error {
+	for key, values := range c.header {
+		op.Header[key] = values
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	return a.handler(ctx, op)
+}
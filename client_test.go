@@ -0,0 +1,368 @@
+package magicbell
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	metrics := &fakeMetrics{}
+	client := NewClient(
+		WithAPIKey("key"),
+		WithAPISecret("secret"),
+		WithBaseURL("https://example.com"),
+		WithTimeout(time.Second),
+		WithoutValidation(),
+		WithMetrics(metrics),
+	)
+
+	config := client.api.config
+	assert.Equal(t, "key", config.APIKey)
+	assert.Equal(t, "secret", config.APISecret)
+	assert.Equal(t, "https://example.com", config.BaseURL)
+	assert.Equal(t, time.Second, *config.Timeout)
+	assert.True(t, config.SkipValidation)
+	assert.Equal(t, metrics, config.Metrics)
+	assert.NotNil(t, client.Templates)
+	assert.Same(t, config.Templates, client.Templates)
+	assert.Equal(t, client.api, client.API())
+}
+
+func TestNewClient_WithConfig(t *testing.T) {
+	client := NewClient(WithBaseURL("https://example.com"), WithConfig(validConfig), WithAPIKey("other"))
+
+	config := client.api.config
+	assert.Equal(t, "other", config.APIKey)
+	assert.Equal(t, "secret", config.APISecret)
+	assert.Equal(t, defaultAPIURL, config.BaseURL)
+}
+
+func TestUsersService_Create(t *testing.T) {
+	for _, test := range createUserTests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, "/users", http.MethodPost, test.httpStatus, func(config Config) {
+				client := NewClient(WithConfig(config))
+				test.Run(t, func(req CreateUserRequest) (*User, error) {
+					return client.Users.Create(context.Background(), req)
+				})
+			})
+		})
+	}
+}
+
+func TestUsersService_GenerateEmailHMAC(t *testing.T) {
+	client := NewClient(WithConfig(validConfig))
+	assert.Equal(t, client.API().GenerateUserEmailHMAC("hana@magicbell.io"), client.Users.GenerateEmailHMAC("hana@magicbell.io"))
+}
+
+func TestClient_VerifyCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		httpStatus  int
+		errorAssert func(*testing.T, error)
+	}{
+		{"valid", http.StatusOK, nil},
+		{"incorrect api key", http.StatusUnauthorized, assertAPIError(APIErrorCodeIncorrectAPIKey, "The API key is incorrect")},
+		{"server error", http.StatusInternalServerError, assertInternalServerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, "/users", http.MethodGet, test.httpStatus, func(config Config) {
+				err := NewClient(WithConfig(config)).VerifyCredentials(context.Background())
+				if test.errorAssert == nil {
+					assert.NoError(t, err)
+				} else {
+					test.errorAssert(t, err)
+				}
+			})
+		})
+	}
+
+	t.Run("missing secret", func(t *testing.T) {
+		err := NewClient(WithAPIKey("key")).VerifyCredentials(context.Background())
+		require.Error(t, err)
+		assert.True(t, IsValidationErrors(err))
+	})
+}
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		endpoint     string
+		body         interface{}
+		httpStatus   int
+		response     string
+		expectedOut  string
+		errorAssert  func(*testing.T, error)
+		expectedBody string
+	}{
+		{
+			name:        "get with query",
+			method:      http.MethodGet,
+			endpoint:    "/users?page=2",
+			httpStatus:  http.StatusOK,
+			response:    `{"users":[]}`,
+			expectedOut: `{"users":[]}`,
+		},
+		{
+			name:         "post with body",
+			method:       http.MethodPost,
+			endpoint:     "users",
+			body:         json.RawMessage(`{"user": {"email": "hana@magicbell.io"}}`),
+			httpStatus:   http.StatusCreated,
+			response:     `{"user":{"id":"123"}}`,
+			expectedOut:  `{"user":{"id":"123"}}`,
+			expectedBody: `{"user":{"email":"hana@magicbell.io"}}`,
+		},
+		{
+			name:        "api errors",
+			method:      http.MethodGet,
+			endpoint:    "users",
+			httpStatus:  http.StatusUnauthorized,
+			response:    `{"errors":[{"code":"incorrect_api_key","message":"The API key is incorrect"}]}`,
+			expectedOut: `{"errors":[{"code":"incorrect_api_key","message":"The API key is incorrect"}]}`,
+			errorAssert: assertAPIError(APIErrorCodeIncorrectAPIKey, "The API key is incorrect"),
+		},
+		{
+			name:       "client error without api errors",
+			method:     http.MethodGet,
+			endpoint:   "users",
+			httpStatus: http.StatusTooManyRequests,
+			response:   `{}`,
+			errorAssert: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "magicbell-go/api: HTTP 429 Too Many Requests")
+			},
+		},
+		{
+			name:        "not found",
+			method:      http.MethodGet,
+			endpoint:    "users/123",
+			httpStatus:  http.StatusNotFound,
+			response:    `{"errors":[{"message":"Could not find the user"}]}`,
+			expectedOut: `{"errors":[{"message":"Could not find the user"}]}`,
+			errorAssert: func(t *testing.T, err error) {
+				assert.True(t, IsNotFoundError(err))
+				assert.EqualError(t, err, "HTTP 404 Not Found: Could not find the user")
+			},
+		},
+		{
+			name:       "not found without json",
+			method:     http.MethodGet,
+			endpoint:   "unknown",
+			httpStatus: http.StatusNotFound,
+			response:   `<html>Not Found</html>`,
+			errorAssert: func(t *testing.T, err error) {
+				assert.Equal(t, NotFoundError{}, err)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.method, r.Method)
+				assert.Equal(t, "/"+strings.TrimPrefix(test.endpoint, "/"), r.URL.RequestURI())
+				assert.Equal(t, "key", r.Header.Get(apiKeyHeader))
+				body, _ := ioutil.ReadAll(r.Body)
+				assert.Equal(t, test.expectedBody, string(body))
+
+				w.WriteHeader(test.httpStatus)
+				_, _ = w.Write([]byte(test.response))
+			}))
+			defer srv.Close()
+
+			var out json.RawMessage
+			err := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL)).Do(context.Background(), test.method, test.endpoint, test.body, &out)
+			if test.errorAssert == nil {
+				assert.NoError(t, err)
+			} else {
+				test.errorAssert(t, err)
+			}
+			if test.expectedOut != "" {
+				assert.JSONEq(t, test.expectedOut, string(out))
+			}
+		})
+	}
+}
+
+func TestNotificationsService_List(t *testing.T) {
+	read := false
+	tests := []struct {
+		name        string
+		user        UserIdentity
+		req         ListNotificationsRequest
+		checkHeader func(*testing.T, http.Header)
+		query       string
+	}{
+		{
+			name: "email",
+			user: UserIdentity{Email: "hana@magicbell.io", ExternalID: "56780"},
+			checkHeader: func(t *testing.T, header http.Header) {
+				assert.Equal(t, "hana@magicbell.io", header.Get(userEmailHeader))
+				assert.Empty(t, header.Get(userExternalIDHeader))
+			},
+		},
+		{
+			name: "external id with filters",
+			user: UserIdentity{ExternalID: "56780"},
+			req:  ListNotificationsRequest{Page: 1, PerPage: 2, Read: &read, Category: "new_message"},
+			checkHeader: func(t *testing.T, header http.Header) {
+				assert.Empty(t, header.Get(userEmailHeader))
+				assert.Equal(t, "56780", header.Get(userExternalIDHeader))
+			},
+			query: "category=new_message&page=1&per_page=2&read=false",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				test.checkHeader(t, r.Header)
+				assert.Equal(t, test.query, r.URL.RawQuery)
+				http.ServeFile(w, r, "testdata/api/notifications_get_200.json")
+			}))
+			defer srv.Close()
+
+			client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+			list, err := client.Notifications.List(context.Background(), test.user, test.req)
+			require.NoError(t, err)
+
+			assert.Equal(t, 2, list.Total)
+			assert.Equal(t, 1, list.CurrentPage)
+			assert.Equal(t, 1, list.UnreadCount)
+			require.Len(t, list.Notifications, 2)
+
+			first := list.Notifications[0]
+			assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", first.ID)
+			assert.Equal(t, "Welcome to MagicBell", first.Title)
+			assert.Equal(t, "new_message", first.Category)
+			assert.Equal(t, time.Unix(1612818080, 0).UTC(), first.SentAt.Time)
+			assert.Nil(t, first.ReadAt)
+
+			second := list.Notifications[1]
+			require.NotNil(t, second.ReadAt)
+			assert.Equal(t, time.Unix(1612731700, int64(500*time.Millisecond)).UTC(), second.ReadAt.Time)
+		})
+	}
+}
+
+func TestNotificationsService_List_MissingUser(t *testing.T) {
+	client := NewClient(WithConfig(validConfig))
+	list, err := client.Notifications.List(context.Background(), UserIdentity{}, ListNotificationsRequest{})
+	assert.True(t, IsValidationErrors(err))
+	assert.Nil(t, list)
+}
+
+func TestCallOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "create-user-1", r.Header.Get(idempotencyKeyHeader))
+		assert.Equal(t, "mbctl", r.Header.Get("X-Source"))
+		assert.Equal(t, "key", r.Header.Get(apiKeyHeader))
+		http.ServeFile(w, r, "testdata/api/users_post_200.json")
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	user, err := client.Users.Create(context.Background(), initialCreateUserRequest,
+		WithIdempotencyKey("create-user-1"),
+		WithHeader("X-Source", "mbctl"),
+		WithHeader(apiKeyHeader, "overridden"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c", user.ID)
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	_, err := client.Users.Create(context.Background(), initialCreateUserRequest, WithCallTimeout(10*time.Millisecond))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNotificationsService_CreateBulk_IdempotencyKey(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		mu.Unlock()
+
+		data, err := ioutil.ReadFile("testdata/api/notifications_post_201.json")
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	request := initialCreateNotificationRequest
+	request.Recipients = bulkRecipients(5)
+
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	result, err := client.Notifications.CreateBulk(context.Background(), request, BulkOptions{ChunkSize: 2}, WithIdempotencyKey("launch"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Failed())
+
+	sort.Strings(keys)
+	assert.Equal(t, []string{"launch-0", "launch-1", "launch-2"}, keys)
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "seconds", data: "1612818080", expected: time.Unix(1612818080, 0).UTC()},
+		{name: "fractional seconds", data: "1612818080.25", expected: time.Unix(1612818080, int64(250*time.Millisecond)).UTC()},
+		{name: "RFC 3339", data: `"2021-02-08T21:01:20Z"`, expected: time.Unix(1612818080, 0).UTC()},
+		{name: "null", data: "null"},
+		{name: "invalid string", data: `"yesterday"`, wantErr: true},
+		{name: "invalid value", data: "true", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var ts Timestamp
+			err := ts.UnmarshalJSON([]byte(test.data))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.expected.Equal(ts.Time), "expected %s, got %s", test.expected, ts.Time)
+		})
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	data, err := Timestamp{time.Unix(1612818080, 0)}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "1612818080", string(data))
+
+	data, err = Timestamp{}.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type apiOptions struct {
+	Fields   []string
+	Input    string
+	Paginate bool
+}
+
+// fields returns the key=value pairs given with --field.
+func (o *apiOptions) fields() (map[string]string, error) {
+	fields := map[string]string{}
+	for _, rawField := range o.Fields {
+		values := strings.SplitN(rawField, "=", 2)
+		if len(values) != 2 {
+			return nil, fmt.Errorf("expected '=' in field, got %s", rawField)
+		}
+		fields[values[0]] = values[1]
+	}
+	return fields, nil
+}
+
+// input returns the JSON request body read from the --input file, or stdin for -.
+func (o *apiOptions) input() (json.RawMessage, error) {
+	var data []byte
+	var err error
+	if o.Input == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(o.Input)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read input: %w", err)
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("input %s is not valid JSON", o.Input)
+	}
+	return data, nil
+}
+
+// request returns the endpoint and body of the request. The fields are sent in the query string of GET and
+// DELETE requests, or when --input is the body, and as a JSON object body otherwise.
+func (o *apiOptions) request(method, path string) (*url.URL, interface{}, error) {
+	endpoint, err := url.Parse(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	fields, err := o.fields()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body interface{}
+	if o.Input != "" {
+		if body, err = o.input(); err != nil {
+			return nil, nil, err
+		}
+	} else if method != http.MethodGet && method != http.MethodDelete && len(fields) > 0 {
+		body, fields = fields, nil
+	}
+
+	query := endpoint.Query()
+	for key, value := range fields {
+		query.Set(key, value)
+	}
+	endpoint.RawQuery = query.Encode()
+	return endpoint, body, nil
+}
+
+// responseIDs returns the ids of the resources in a response body: the id of the top level object,
+// and the ids of the objects and lists of objects it contains, such as {"user": {...}} or {"users": [...]}.
+func responseIDs(response json.RawMessage) []string {
+	var ids []string
+	addID := func(value interface{}) {
+		if object, ok := value.(map[string]interface{}); ok && object["id"] != nil {
+			ids = append(ids, fmt.Sprint(object["id"]))
+		}
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(response, &object); err != nil {
+		return nil
+	}
+	if object["id"] != nil {
+		addID(object)
+		return ids
+	}
+	for _, value := range object {
+		addID(value)
+		if list, ok := value.([]interface{}); ok {
+			for _, item := range list {
+				addID(item)
+			}
+		}
+	}
+	return ids
+}
+
+// writeJSON writes the response body indented, responses without a body are not written.
+func writeJSON(w io.Writer, response json.RawMessage) {
+	if string(response) == "null" {
+		return
+	}
+	data, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		// not JSON, e.g. an HTML error page
+		data = response
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+var (
+	apiCmd = &cobra.Command{
+		Use:   "api <method> <path>",
+		Short: "Make an authenticated request to any endpoint of the MagicBell API",
+		Long: `Make an authenticated request to any endpoint of the MagicBell API, with the API key and secret
+of the config, and print the JSON response.
+
+The --field key=value pairs are sent in the query string of GET and DELETE requests, and as a JSON object
+otherwise. Use --input to send a JSON file as the request body instead, or - to read it from stdin.
+
+With --paginate, the pages of a GET request are fetched until the total_pages of the response
+and printed one after another.
+
+The command fails when the response is an HTTP error, after printing the JSON response body, if any.`,
+		Example: `mbctl api get users --field per_page=10
+mbctl api get users --paginate -o id
+mbctl api post users --input user.json
+mbctl api put users/email:hana@magicbell.io --input - < user.json
+mbctl api delete users/external_id:56780`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			method := strings.ToUpper(args[0])
+			if apiOpts.Paginate && method != http.MethodGet {
+				return fmt.Errorf("--paginate can only be used with GET requests")
+			}
+
+			endpoint, body, err := apiOpts.request(method, args[1])
+			if err != nil {
+				return err
+			}
+
+			var pages []json.RawMessage
+			for page := 1; ; page++ {
+				if apiOpts.Paginate {
+					query := endpoint.Query()
+					query.Set("page", strconv.Itoa(page))
+					endpoint.RawQuery = query.Encode()
+				}
+
+				var response json.RawMessage
+				err := client.Do(cmd.Context(), method, endpoint.String(), body, &response)
+				if err != nil {
+					if len(response) > 0 {
+						writeJSON(os.Stdout, response)
+					}
+					return err
+				}
+				if len(response) == 0 {
+					// e.g. 204 No Content
+					response = json.RawMessage("null")
+				}
+				pages = append(pages, response)
+
+				var pagination struct {
+					TotalPages int `json:"total_pages"`
+				}
+				_ = json.Unmarshal(response, &pagination)
+				if !apiOpts.Paginate || page >= pagination.TotalPages {
+					break
+				}
+			}
+
+			result := commandResult{
+				Value: pages,
+				Items: make([]interface{}, len(pages)),
+				Table: func(w io.Writer) {
+					for _, page := range pages {
+						writeJSON(w, page)
+					}
+				},
+			}
+			if len(pages) == 1 {
+				result.Value = pages[0]
+			}
+			for i, page := range pages {
+				result.Items[i] = page
+				result.IDs = append(result.IDs, responseIDs(page)...)
+			}
+			return printResult(result)
+		},
+	}
+
+	apiOpts = &apiOptions{}
+)
+
+func init() {
+	apiCmd.Flags().StringArrayVarP(&apiOpts.Fields, "field", "F", nil, "A key=value field of the request, may be repeated")
+	apiCmd.Flags().StringVar(&apiOpts.Input, "input", "", "A JSON file to send as the request body, or - for stdin")
+	apiCmd.Flags().BoolVar(&apiOpts.Paginate, "paginate", false, "Fetch all pages of a GET request")
+
+	rootCmd.AddCommand(apiCmd)
+}
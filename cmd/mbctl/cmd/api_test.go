@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+// runCommand runs mbctl with args against the MagicBell API at baseURL, and returns what it printed to stdout.
+func runCommand(t *testing.T, baseURL string, args ...string) (string, error) {
+	dir, err := ioutil.TempDir("", "mbctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "config.yaml")
+	config := fmt.Sprintf("apikey: key\napisecret: secret\nbaseurl: %s\n", baseURL)
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(config), 0600))
+
+	return executeCommand(t, append([]string{"--config", configPath}, args...)...)
+}
+
+// executeCommand runs mbctl with args, and returns what it printed to stdout.
+func executeCommand(t *testing.T, args ...string) (string, error) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	rootCmd.SetArgs(args)
+	err = rootCmd.ExecuteContext(context.Background())
+	w.Close()
+
+	out, readErr := ioutil.ReadAll(r)
+	require.NoError(t, readErr)
+	return string(out), err
+}
+
+func TestAPICmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpStatus int
+		response   string
+		expected   string
+		checkErr   func(*testing.T, error)
+	}{
+		{
+			name:       "ok",
+			httpStatus: http.StatusOK,
+			response:   `{"user":{"id":"123"}}`,
+			expected:   "{\n  \"user\": {\n    \"id\": \"123\"\n  }\n}\n",
+			checkErr: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:       "not found",
+			httpStatus: http.StatusNotFound,
+			response:   `{"errors":[{"message":"Could not find the user"}]}`,
+			expected:   "{\n  \"errors\": [\n    {\n      \"message\": \"Could not find the user\"\n    }\n  ]\n}\n",
+			checkErr: func(t *testing.T, err error) {
+				assert.True(t, magicbell.IsNotFoundError(err))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/users/123", r.URL.Path)
+				w.WriteHeader(test.httpStatus)
+				_, _ = w.Write([]byte(test.response))
+			}))
+			defer srv.Close()
+
+			out, err := runCommand(t, srv.URL, "api", "get", "users/123")
+			test.checkErr(t, err)
+			assert.Equal(t, test.expected, out)
+		})
+	}
+}
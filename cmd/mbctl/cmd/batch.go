@@ -0,0 +1,477 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+const (
+	// customAttributesPrefix is the prefix of the columns holding custom attributes
+	customAttributesPrefix = "custom_attributes."
+
+	batchFormatCSV   = "csv"
+	batchFormatJSONL = "jsonl"
+
+	batchProgressInterval = 2 * time.Second
+)
+
+// batchRow is a row of a batch file, with its values keyed by column name.
+type batchRow struct {
+	// Number is the position of the row in the file, starting at 1 and not counting the CSV header
+	Number int
+	// Values are the values of the row, CSV cells are strings while JSONL values keep their JSON type
+	Values map[string]interface{}
+}
+
+// customAttributes returns the custom attributes of the row, from the custom_attributes object of JSONL rows
+// and the columns prefixed with custom_attributes. Empty cells are left out, see customAttributeColumn for
+// how the other cells are read.
+func (r batchRow) customAttributes() (magicbell.CustomAttributes, error) {
+	var attrs magicbell.CustomAttributes
+	set := func(key string, value interface{}) {
+		if attrs == nil {
+			attrs = magicbell.CustomAttributes{}
+		}
+		attrs[key] = value
+	}
+
+	if object, ok := r.Values["custom_attributes"].(map[string]interface{}); ok {
+		for key, value := range object {
+			set(key, value)
+		}
+	}
+	for key, value := range r.Values {
+		if !strings.HasPrefix(key, customAttributesPrefix) || value == "" {
+			continue
+		}
+
+		name, value, err := customAttributeColumn(key, value)
+		if err != nil {
+			return nil, err
+		}
+		set(name, value)
+	}
+	return attrs, nil
+}
+
+// customAttributeColumn returns the name and value of the custom attribute in the column key, which is prefixed
+// with custom_attributes. Columns named custom_attributes.<name>: hold JSON values, like the Key:=JSON of
+// --custom-attribute, so numbers and booleans keep their type. In other columns, only cells holding a JSON
+// object or array are decoded. Empty cells are empty strings.
+func customAttributeColumn(key string, value interface{}) (string, interface{}, error) {
+	name := strings.TrimPrefix(key, customAttributesPrefix)
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return strings.TrimSuffix(name, ":"), value, nil
+	}
+
+	if strings.HasSuffix(name, ":") {
+		name = strings.TrimSuffix(name, ":")
+		var decoded interface{}
+		if err := decodeJSON([]byte(s), &decoded); err != nil {
+			return "", nil, fmt.Errorf("invalid JSON value for custom attribute %s: %w", name, err)
+		}
+		return name, decoded, nil
+	}
+	if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err == nil {
+			return name, decoded, nil
+		}
+	}
+	return name, s, nil
+}
+
+// batchOptions are the flags shared by the commands processing a batch file.
+type batchOptions struct {
+	File        string
+	Format      string
+	Columns     []string // Column=field
+	Concurrency int
+	Rate        float64
+	ResultsFile string
+	Resume      bool
+}
+
+func (o *batchOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "The CSV or JSONL file to read rows from")
+	cmd.Flags().StringVar(&o.Format, "format", "", "The format of the file: csv or jsonl. Inferred from the file extension if not set")
+	cmd.Flags().StringArrayVar(&o.Columns, "column", nil, "Rename a column of the file to a field, in the Column=field format")
+	cmd.Flags().IntVar(&o.Concurrency, "concurrency", 4, "The maximum number of rows to process in parallel")
+	cmd.Flags().Float64Var(&o.Rate, "rate", 0, "The maximum number of rows to process per second, 0 for no limit")
+	cmd.Flags().StringVar(&o.ResultsFile, "results", "", "A JSONL file to write the result of every row to")
+	cmd.Flags().BoolVar(&o.Resume, "resume", false, "Skip the rows which succeeded according to the --results file, and append to it")
+	_ = cmd.MarkFlagRequired("file")
+}
+
+// readRows reads the rows of the batch file, renaming the columns given with --column.
+func (o *batchOptions) readRows() ([]batchRow, error) {
+	renames := make(map[string]string, len(o.Columns))
+	for _, rawColumn := range o.Columns {
+		values := strings.SplitN(rawColumn, "=", 2)
+		if len(values) != 2 || values[0] == "" || values[1] == "" {
+			return nil, fmt.Errorf("expected Column=field in --column, got %s", rawColumn)
+		}
+		renames[values[0]] = values[1]
+	}
+
+	format := o.Format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(o.File)) {
+		case ".csv":
+			format = batchFormatCSV
+		case ".jsonl", ".ndjson":
+			format = batchFormatJSONL
+		default:
+			return nil, fmt.Errorf("unable to infer the format of %s, use --format csv or --format jsonl", o.File)
+		}
+	}
+
+	f, err := os.Open(o.File)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open batch file: %w", err)
+	}
+	defer f.Close()
+
+	var rows []batchRow
+	switch format {
+	case batchFormatCSV:
+		rows, err = readCSVRows(f)
+	case batchFormatJSONL:
+		rows, err = readJSONLRows(f)
+	default:
+		return nil, fmt.Errorf("unknown batch file format %q, expected csv or jsonl", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read batch file %s: %w", o.File, err)
+	}
+
+	for _, row := range rows {
+		for from, to := range renames {
+			if value, ok := row.Values[from]; ok {
+				delete(row.Values, from)
+				row.Values[to] = value
+			}
+		}
+	}
+	return rows, nil
+}
+
+// readCSVRows reads a CSV file whose first line is the header. Empty cells are empty strings, so templates
+// referencing their column still render.
+func readCSVRows(r io.Reader) ([]batchRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	var rows []batchRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		row := batchRow{Number: len(rows) + 1, Values: map[string]interface{}{}}
+		for i, value := range record {
+			row.Values[header[i]] = value
+		}
+		rows = append(rows, row)
+	}
+}
+
+// readJSONLRows reads a file with a JSON object per line. Blank lines are ignored.
+func readJSONLRows(r io.Reader) ([]batchRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []batchRow
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		row := batchRow{Number: len(rows) + 1}
+		if err := json.Unmarshal(scanner.Bytes(), &row.Values); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// batchRowResult is the result of processing a row, written as a line of the --results file.
+type batchRowResult struct {
+	// Row is the number of the row in the batch file
+	Row int `json:"row"`
+	// ID is the id of the notification or user created for the row
+	ID string `json:"id,omitempty"`
+	// Email is the email of the recipient or user of the row
+	Email string `json:"email,omitempty"`
+	// ExternalID is the external id of the recipient or user of the row
+	ExternalID string `json:"external_id,omitempty"`
+	// Action is what was done for the row, for example created or updated
+	Action string `json:"action,omitempty"`
+	// Error is why processing the row failed
+	Error string `json:"error,omitempty"`
+}
+
+// batchResults collects the results of the rows and writes them to the --results file, if any.
+type batchResults struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	results []batchRowResult
+	err     error
+}
+
+// openResults opens the --results file, truncating it unless --resume is set.
+// It also returns the numbers of the rows which already succeeded, when resuming.
+func (o *batchOptions) openResults() (*batchResults, map[int]bool, error) {
+	results := &batchResults{}
+	if o.ResultsFile == "" {
+		if o.Resume {
+			return nil, nil, fmt.Errorf("--resume requires --results")
+		}
+		return results, nil, nil
+	}
+
+	var succeeded map[int]bool
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if o.Resume {
+		var err error
+		if succeeded, err = readSucceededRows(o.ResultsFile); err != nil {
+			return nil, nil, err
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(o.ResultsFile, flags, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open results file: %w", err)
+	}
+	results.file = f
+	results.encoder = json.NewEncoder(f)
+	return results, succeeded, nil
+}
+
+// readSucceededRows returns the rows whose last result in the results file has no error.
+func readSucceededRows(path string) (map[int]bool, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return map[int]bool{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to open results file: %w", err)
+	}
+	defer f.Close()
+
+	succeeded := map[int]bool{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var result batchRowResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			return nil, fmt.Errorf("unable to read results file %s, line %d: %w", path, line, err)
+		}
+		succeeded[result.Row] = result.Error == ""
+	}
+	return succeeded, scanner.Err()
+}
+
+// add records the result of a row, writing it to the results file right away so it survives interruptions.
+// It returns an error when the row failed.
+func (r *batchResults) add(result batchRowResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, result)
+	if r.encoder != nil && r.err == nil {
+		if err := r.encoder.Encode(result); err != nil {
+			r.err = fmt.Errorf("unable to write results file: %w", err)
+		}
+	}
+
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	return nil
+}
+
+// close closes the results file, returning the first error which happened while writing it.
+func (r *batchResults) close() error {
+	if r.file == nil {
+		return nil
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = fmt.Errorf("unable to close results file: %w", err)
+	}
+	return r.err
+}
+
+// summary returns the commandResult of the processed rows, in the order of the batch file.
+func (r *batchResults) summary(skipped int) commandResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]batchRowResult, len(r.results))
+	copy(results, r.results)
+	sort.Slice(results, func(i, j int) bool { return results[i].Row < results[j].Row })
+
+	summary := batchSummary{Skipped: skipped, Results: results}
+	commandResult := commandResult{Value: &summary, Items: make([]interface{}, len(results))}
+	for i, result := range results {
+		commandResult.Items[i] = result
+		if result.Error != "" {
+			summary.Failed++
+			continue
+		}
+		summary.Succeeded++
+		if result.ID != "" {
+			commandResult.IDs = append(commandResult.IDs, result.ID)
+		}
+	}
+
+	commandResult.Table = func(w io.Writer) {
+		fmt.Fprintf(w, "%d rows succeeded, %d failed, %d skipped\n", summary.Succeeded, summary.Failed, summary.Skipped)
+		if summary.Failed == 0 {
+			return
+		}
+
+		fmt.Fprintln(w, "\nROW\tEMAIL\tEXTERNAL ID\tERROR")
+		for _, result := range results {
+			if result.Error != "" {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", result.Row, result.Email, result.ExternalID, result.Error)
+			}
+		}
+	}
+	return commandResult
+}
+
+// batchSummary is the result printed by the commands processing a batch file.
+type batchSummary struct {
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Skipped   int              `json:"skipped"`
+	Results   []batchRowResult `json:"results"`
+}
+
+func (s batchSummary) err() error {
+	if s.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d rows failed", s.Failed, s.Failed+s.Succeeded)
+}
+
+// runBatch calls process for every row, with at most --concurrency rows processed in parallel
+// and at most --rate rows started per second. The progress is logged periodically.
+func (o *batchOptions) runBatch(ctx context.Context, rows []batchRow, process func(ctx context.Context, row batchRow) error) {
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var throttle <-chan time.Time
+	if o.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / o.Rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var processed, failed int64
+	logProgress := func() {
+		logrus.Infof("Processed %d of %d rows, %d failed", atomic.LoadInt64(&processed), len(rows), atomic.LoadInt64(&failed))
+	}
+	progress := time.NewTicker(batchProgressInterval)
+	defer progress.Stop()
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-progress.C:
+				logProgress()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+rows:
+	for _, row := range rows {
+		if throttle != nil {
+			select {
+			case <-throttle:
+			case <-ctx.Done():
+				break rows
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break rows
+		}
+
+		wg.Add(1)
+		go func(row batchRow) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := process(ctx, row); err != nil {
+				atomic.AddInt64(&failed, 1)
+			}
+			atomic.AddInt64(&processed, 1)
+		}(row)
+	}
+
+	wg.Wait()
+	close(done)
+	logProgress()
+}
+
+// stringValue returns the value of the row as a string, JSON values other than strings are encoded as JSON.
+func stringValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
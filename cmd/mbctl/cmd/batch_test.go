@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+func TestReadCSVRows(t *testing.T) {
+	rows, err := readCSVRows(strings.NewReader("email,first_name,custom_attributes.plan\n" +
+		"hana@magicbell.io,Hana,pro\n" +
+		"john@example.com,,\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, batchRow{Number: 2, Values: map[string]interface{}{
+		"email":                  "john@example.com",
+		"first_name":             "",
+		"custom_attributes.plan": "",
+	}}, rows[1])
+	attrs, err := rows[1].customAttributes()
+	require.NoError(t, err)
+	assert.Nil(t, attrs)
+	attrs, err = rows[0].customAttributes()
+	require.NoError(t, err)
+	assert.Equal(t, magicbell.CustomAttributes{"plan": "pro"}, attrs)
+}
+
+func TestBatchRow_CustomAttributes(t *testing.T) {
+	rows, err := readCSVRows(strings.NewReader("email,custom_attributes.plan,custom_attributes.seats:,custom_attributes.order\n" +
+		"hana@magicbell.io,pro,12,\"{\"\"id\"\": 1}\"\n" +
+		"john@example.com,,twelve,\n"))
+	require.NoError(t, err)
+
+	attrs, err := rows[0].customAttributes()
+	require.NoError(t, err)
+	assert.Equal(t, magicbell.CustomAttributes{
+		"plan":  "pro",
+		"seats": json.Number("12"),
+		"order": map[string]interface{}{"id": float64(1)},
+	}, attrs)
+
+	_, err = rows[1].customAttributes()
+	assert.EqualError(t, err, "invalid JSON value for custom attribute seats: invalid character 'w' in literal true (expecting 'r')")
+}
+
+func TestNotificationsSendBatch_BlankCell(t *testing.T) {
+	rows, err := readCSVRows(strings.NewReader("email,first_name,custom_attributes.plan\n" +
+		"john@example.com,,\n"))
+	require.NoError(t, err)
+
+	registry := magicbell.NewTemplateRegistry()
+	require.NoError(t, registry.Register(sendBatchTemplateName, magicbell.NotificationTemplate{
+		Title:   "Hi {{.first_name}}",
+		Content: "Your {{.custom_attributes.plan}} plan",
+	}))
+
+	opts := &notificationsSendBatchOptions{}
+	recipient, err := opts.recipient(rows[0])
+	require.NoError(t, err)
+	assert.Equal(t, magicbell.NotificationRecipient{Email: "john@example.com"}, recipient)
+
+	req, err := opts.notification(registry, rows[0], recipient)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi ", req.Title)
+	assert.Equal(t, "Your  plan", req.Content)
+	assert.Nil(t, req.CustomAttributes)
+}
+
+func TestNotificationsSendBatch_CustomAttributes(t *testing.T) {
+	rows, err := readCSVRows(strings.NewReader("email,first_name,phone_numbers,custom_attributes.plan,custom_attributes.seats:\n" +
+		"john@example.com,John,+15555550100,pro,3\n"))
+	require.NoError(t, err)
+
+	registry := magicbell.NewTemplateRegistry()
+	require.NoError(t, registry.Register(sendBatchTemplateName, magicbell.NotificationTemplate{
+		Title:   "Hi {{.first_name}} ({{.email}})",
+		Content: "Your {{.custom_attributes.plan}} plan has {{.custom_attributes.seats}} seats",
+	}))
+
+	opts := &notificationsSendBatchOptions{}
+	recipient, err := opts.recipient(rows[0])
+	require.NoError(t, err)
+
+	req, err := opts.notification(registry, rows[0], recipient)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi John (john@example.com)", req.Title)
+	assert.Equal(t, "Your pro plan has 3 seats", req.Content)
+	assert.Equal(t, magicbell.CustomAttributes{"plan": "pro", "seats": json.Number("3")}, req.CustomAttributes)
+	assert.Equal(t, []magicbell.NotificationRecipient{recipient}, req.Recipients)
+}
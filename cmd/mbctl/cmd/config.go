@@ -1,10 +1,307 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+const (
+	// envProfile is the environment variable selecting the profile, like --profile
+	envProfile = "MAGICBELL_PROFILE"
+	// envAllowExecSecrets is the environment variable allowing exec: secret references, like --allow-exec-secrets
+	envAllowExecSecrets = "MAGICBELL_ALLOW_EXEC_SECRETS"
+	// currentProfileKey is the config file key of the profile used when none is selected
+	currentProfileKey = "current_profile"
+	// profilesKey is the config file key of the named profiles
+	profilesKey = "profiles"
+	// configOptionalAnnotation marks the commands which work without the config file or the selected profile,
+	// so they can create or fix them
+	configOptionalAnnotation = "mbctl/config-optional"
+)
+
+// configKey is a setting which can be stored in the config file.
+type configKey struct {
+	// Name is the viper key of the setting
+	Name string
+	// FileKey is the key of the setting in the config file, as encoded by magicbell.Config
+	FileKey string
+	// parse converts the value given to 'config set' to the value stored in the config file, if not a string
+	parse func(string) (interface{}, error)
+}
+
+var configKeys = []configKey{
+	{Name: "APIKey", FileKey: "apikey"},
+	{Name: "APISecret", FileKey: "apisecret"},
+	{Name: "BaseURL", FileKey: "baseurl"},
+	{Name: "Timeout", FileKey: "timeout", parse: func(s string) (interface{}, error) {
+		if _, err := time.ParseDuration(s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}},
+	{Name: "SkipValidation", FileKey: "skipvalidation", parse: func(s string) (interface{}, error) {
+		return strconv.ParseBool(s)
+	}},
+	{Name: currentProfileKey, FileKey: currentProfileKey},
+}
+
+// findConfigKey returns the configKey named name. Names are matched case insensitively and ignoring
+// underscores and dashes, like magicbell.LoadConfig does, so APIKey, api_key and api-key are equivalent.
+func findConfigKey(name string) (configKey, error) {
+	normalize := func(s string) string { return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(s)) }
+
+	var names []string
+	for _, key := range configKeys {
+		if normalize(key.Name) == normalize(name) {
+			return key, nil
+		}
+		names = append(names, key.FileKey)
+	}
+	return configKey{}, fmt.Errorf("unknown config key %q, expected one of: %s", name, strings.Join(names, ", "))
+}
 
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Group of config related commands",
+	Long: `Group of config related commands.
+
+The config file has the settings of a single MagicBell project at the top level,
+and may have named profiles for other projects:
+
+  apikey: my-dev-key
+  apisecret: my-dev-secret
+  current_profile: staging
+  profiles:
+    staging:
+      apikey: my-staging-key
+      apisecret: my-staging-secret
+    production:
+      apikey: my-production-key
+      apisecret: env:MAGICBELL_PRODUCTION_SECRET
+
+The profile is chosen with --profile, the MAGICBELL_PROFILE environment variable or current_profile,
+in that order. Settings missing from the profile are read from the top level.
+
+The API key and secret may be references to secrets stored elsewhere: file:/run/secrets/magicbell,
+env:MY_SECRET_VAR, or exec:my-credential-helper get magicbell which runs the command and uses its output.
+An exec: reference is only run from a config file you chose: ~/.config/magicbell/config.yaml or the file
+given with --config. A config.yaml found in the current directory, such as one checked into
+a repository you cloned, may not run commands unless you pass --allow-exec-secrets or set
+MAGICBELL_ALLOW_EXEC_SECRETS=true.`,
+}
+
+// applyProfile merges the settings of the selected profile, if any, over the top level settings of the config file.
+// It returns the name of the profile.
+func applyProfile() (string, error) {
+	profile := viper.GetString(currentProfileKey)
+	if profile == "" {
+		return "", nil
+	}
+
+	settings := viper.Sub(profilesKey + "." + profile)
+	if settings == nil {
+		return profile, fmt.Errorf("profile %q not found in config file, expected one of: %s", profile, strings.Join(profileNames(), ", "))
+	}
+	if err := viper.MergeConfigMap(settings.AllSettings()); err != nil {
+		return profile, fmt.Errorf("unable to read profile %q: %w", profile, err)
+	}
+	return profile, nil
+}
+
+// resolveSecrets returns config with its secret references resolved, refusing to run the exec: references
+// of an untrusted config file.
+func resolveSecrets(config magicbell.Config) (magicbell.Config, error) {
+	if err := checkExecSecrets(config); err != nil {
+		return magicbell.Config{}, err
+	}
+	return config.ResolveSecrets()
+}
+
+// checkExecSecrets returns an error if the API key or secret of config is an exec: reference read from a config
+// file found in the current directory. The config file in the home directory, the one given with --config,
+// flags and environment variables are chosen by the user, so their references are trusted.
+func checkExecSecrets(config magicbell.Config) error {
+	if rootOpts.allowExecSecrets || rootOpts.configLocation != "" {
+		return nil
+	}
+	if allow, _ := strconv.ParseBool(os.Getenv(envAllowExecSecrets)); allow {
+		return nil
+	}
+
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return nil
+	}
+	dirs, err := configSearchDirs()
+	if err != nil {
+		return err
+	}
+	if dir, err := filepath.Abs(filepath.Dir(path)); err == nil && dir == dirs[0] {
+		return nil
+	}
+
+	doc, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	fileRefs := map[string]bool{}
+	addRefs := func(doc yaml.MapSlice) {
+		for _, key := range []string{"apikey", "apisecret"} {
+			if value, ok := configValue(doc, key); ok {
+				fileRefs[fmt.Sprint(value)] = true
+			}
+		}
+	}
+	addRefs(doc)
+	if profiles, ok := configValue(doc, profilesKey); ok {
+		if profiles, ok := profiles.(yaml.MapSlice); ok {
+			for _, item := range profiles {
+				if settings, ok := item.Value.(yaml.MapSlice); ok {
+					addRefs(settings)
+				}
+			}
+		}
+	}
+
+	for _, ref := range []string{config.APIKey, config.APISecret} {
+		if strings.HasPrefix(ref, magicbell.SecretRefExec) && fileRefs[ref] {
+			return fmt.Errorf("config file %s has an %s secret reference, which is not run from a config file found in the "+
+				"current directory; pass --allow-exec-secrets or set %s=true if you trust it", path, magicbell.SecretRefExec, envAllowExecSecrets)
+		}
+	}
+	return nil
+}
+
+// profileNames returns the sorted names of the profiles in the config file.
+func profileNames() []string {
+	var names []string
+	for name := range viper.GetStringMap(profilesKey) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// requestedProfile returns the profile chosen with --profile or MAGICBELL_PROFILE, ignoring current_profile.
+func requestedProfile() string {
+	if flag := rootCmd.Flag("profile"); flag.Changed {
+		return flag.Value.String()
+	}
+	return os.Getenv(envProfile)
+}
+
+// configSearchDirs returns the absolute directories searched for a config file when --config is not given.
+func configSearchDirs() ([]string, error) {
+	dirs := make([]string, len(configSearchPaths))
+	for i, dir := range configSearchPaths {
+		var err error
+		if dirs[i], err = filepath.Abs(os.ExpandEnv(dir)); err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// configFilePath returns the path of the config file read by mbctl, or an error if there is none.
+func configFilePath() (string, error) {
+	if rootOpts.configLocation != "" {
+		return rootOpts.configLocation, nil
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	return "", errors.New("no config file found, create one with 'mbctl config init'")
+}
+
+// readConfigFile returns the content of the config file at path, keeping the order of its keys.
+// It returns an empty document if the file does not exist.
+func readConfigFile(path string) (yaml.MapSlice, error) {
+	data, err := ioutil.ReadFile(path) // #nosec G304
+	if os.IsNotExist(err) {
+		return yaml.MapSlice{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse config file %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// writeConfigFile writes doc to the config file at path, readable only by the current user.
+func writeConfigFile(path string, doc yaml.MapSlice) error {
+	encoded, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("unable to marshal config to yaml: %w", err)
+	}
+
+	// ensure parent directory exists
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to create config parent directory: %w", err)
+	}
+	if err := ioutil.WriteFile(path, encoded, 0600); err != nil {
+		return fmt.Errorf("unable to write config file: %w", err)
+	}
+	// WriteFile does not change the permissions of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("unable to set config file permissions: %w", err)
+	}
+	return nil
+}
+
+// configValue returns the value at keys in doc. Keys are matched case insensitively, like viper does.
+func configValue(doc yaml.MapSlice, keys ...string) (interface{}, bool) {
+	for i, item := range doc {
+		if !strings.EqualFold(fmt.Sprint(item.Key), keys[0]) {
+			continue
+		}
+		if len(keys) == 1 {
+			return doc[i].Value, true
+		}
+		nested, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, false
+		}
+		return configValue(nested, keys[1:]...)
+	}
+	return nil, false
+}
+
+// setConfigValue sets the value at keys in doc, creating the missing nested documents, and returns the updated doc.
+// Keys are matched case insensitively, like viper does.
+func setConfigValue(doc yaml.MapSlice, value interface{}, keys ...string) yaml.MapSlice {
+	for i, item := range doc {
+		if !strings.EqualFold(fmt.Sprint(item.Key), keys[0]) {
+			continue
+		}
+		if len(keys) == 1 {
+			doc[i].Value = value
+		} else {
+			nested, _ := item.Value.(yaml.MapSlice)
+			doc[i].Value = setConfigValue(nested, value, keys[1:]...)
+		}
+		return doc
+	}
+
+	if len(keys) == 1 {
+		return append(doc, yaml.MapItem{Key: keys[0], Value: value})
+	}
+	return append(doc, yaml.MapItem{Key: keys[0], Value: setConfigValue(nil, value, keys[1:]...)})
 }
 
 func init() {
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configKeyValue returns the value of key used by mbctl, from the flags, environment variables, selected profile
+// or config file.
+func configKeyValue(key configKey) interface{} {
+	switch key.Name {
+	case "Timeout":
+		return viper.GetDuration(key.Name).String()
+	case "SkipValidation":
+		return viper.GetBool(key.Name)
+	default:
+		return viper.GetString(key.Name)
+	}
+}
+
+var (
+	configGetCmd = &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a value of the config",
+		Long: `Print a value of the config as used by mbctl, from the flags, environment variables,
+selected profile or config file. Secret references such as env:MY_SECRET_VAR are printed as-is.
+The API secret is redacted like in 'mbctl config view', unless --show-secret is given.
+
+The keys are apikey, apisecret, baseurl, timeout, skipvalidation and current_profile.`,
+		Example: `mbctl config get baseurl
+mbctl config get apikey --profile production
+mbctl config get apisecret --show-secret`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configErr != nil {
+				// the values are printed anyway, to help fixing the config
+				logrus.Warn(configErr)
+			}
+
+			key, err := findConfigKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			value := configKeyValue(key)
+			if key.Name == "APISecret" && !configGetShowSecret {
+				value = redactSecret(fmt.Sprint(value))
+			}
+			return printResult(commandResult{
+				Value: configSetting{Key: key.FileKey, Value: value, Profile: profile},
+				IDs:   []string{fmt.Sprint(value)},
+				Table: func(w io.Writer) { fmt.Fprintln(w, value) },
+			})
+		},
+	}
+
+	configGetShowSecret bool
+)
+
+func init() {
+	configGetCmd.Flags().BoolVar(&configGetShowSecret, "show-secret", false, "Print the API secret instead of redacting it")
+
+	configCmd.AddCommand(configGetCmd)
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigGetCmd_Secret(t *testing.T) {
+	defer func() { configGetShowSecret = false }()
+
+	out, err := runCommand(t, "https://api.magicbell.io", "config", "get", "apisecret")
+	require.NoError(t, err)
+	assert.Equal(t, "********\n", out)
+
+	out, err = runCommand(t, "https://api.magicbell.io", "config", "get", "apisecret", "--show-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret\n", out)
+}
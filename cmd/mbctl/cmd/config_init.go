@@ -2,20 +2,23 @@ package cmd
 
 import (
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 	"path"
 	"strings"
 
 	"github.com/manifoldco/promptui"
 	homedir "github.com/mitchellh/go-homedir"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v2"
-
-	magicbell "github.com/tizz98/magicbell-go"
 )
 
+// configFile is the result of the commands writing the config file.
+type configFile struct {
+	Path string `json:"path"`
+	// Profile is the profile written or selected, if any
+	Profile string `json:"profile,omitempty"`
+}
+
 func notEmptyValidator(s string) error {
 	if len(strings.TrimSpace(s)) == 0 {
 		return fmt.Errorf("cannot be empty")
@@ -27,31 +30,43 @@ var (
 	configInitCmd = &cobra.Command{
 		Use:   "init",
 		Short: "Interactively initialize a config.yaml file for use with this cli",
+		Long: `Interactively initialize a config.yaml file for use with this cli.
+
+With --profile (or MAGICBELL_PROFILE), the API key and secret are saved as a named profile, which is added to
+the config file if it already exists. The profile becomes the current one if there is none yet.
+Otherwise the API key and secret are saved at the top level of the config file, keeping its profiles.`,
+		Example: `mbctl config init
+mbctl config init --profile production`,
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var possibleConfigPaths []string
-
-			if homePath, err := homedir.Expand("~/.config/magicbell/config.yaml"); err == nil {
-				possibleConfigPaths = append(possibleConfigPaths, homePath)
+			configPath := rootOpts.configLocation
+			if configPath == "" {
+				var possibleConfigPaths []string
+
+				if homePath, err := homedir.Expand("~/.config/magicbell/config.yaml"); err == nil {
+					possibleConfigPaths = append(possibleConfigPaths, homePath)
+				}
+
+				if cwd, err := os.Getwd(); err == nil {
+					possibleConfigPaths = append(possibleConfigPaths, path.Join(cwd, "config.yaml"))
+				}
+
+				selector := promptui.Select{
+					Label: "Config output location",
+					Items: possibleConfigPaths,
+				}
+				var err error
+				if _, configPath, err = selector.Run(); err != nil {
+					return err
+				}
 			}
 
-			if cwd, err := os.Getwd(); err == nil {
-				possibleConfigPaths = append(possibleConfigPaths, path.Join(cwd, "config.yaml"))
-			}
-
-			selector := promptui.Select{
-				Label: "Config output location",
-				Items: possibleConfigPaths,
-			}
-			_, configPath, err := selector.Run()
+			doc, err := readConfigFile(configPath)
 			if err != nil {
 				return err
 			}
 
-			// ensure parent directory exists
-			if err := os.MkdirAll(path.Dir(configPath), 0755); err != nil {
-				return fmt.Errorf("unable to create config parent directory: %w", err)
-			}
-
+			// secrets may also be entered as references, e.g. file:/run/secrets/magicbell
 			apiKeyPrompt := promptui.Prompt{
 				Label:     "API Key",
 				AllowEdit: true,
@@ -72,22 +87,35 @@ var (
 				return err
 			}
 
-			config := magicbell.Config{
-				APIKey:    apiKey,
-				APISecret: apiSecret,
-			}
-
-			encoded, err := yaml.Marshal(config)
-			if err != nil {
-				return fmt.Errorf("unable to marshal config to yaml: %w", err)
+			// keys are named like the yaml encoding of magicbell.Config
+			profileName := requestedProfile()
+			if profileName == "" {
+				doc = setConfigValue(doc, apiKey, "apikey")
+				doc = setConfigValue(doc, apiSecret, "apisecret")
+			} else {
+				doc = setConfigValue(doc, apiKey, profilesKey, profileName, "apikey")
+				doc = setConfigValue(doc, apiSecret, profilesKey, profileName, "apisecret")
+				if current, _ := configValue(doc, currentProfileKey); current == nil || current == "" {
+					doc = setConfigValue(doc, profileName, currentProfileKey)
+				}
 			}
 
-			if err := ioutil.WriteFile(configPath, encoded, 0644); err != nil {
-				return fmt.Errorf("unable to write config file: %w", err)
+			if err := writeConfigFile(configPath, doc); err != nil {
+				return err
 			}
 
-			logrus.Infof("Wrote config to %s !", configPath)
-			return nil
+			result := configFile{Path: configPath, Profile: profileName}
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{configPath},
+				Table: func(w io.Writer) {
+					if profileName == "" {
+						fmt.Fprintf(w, "Wrote config to %s\n", configPath)
+					} else {
+						fmt.Fprintf(w, "Wrote profile %s to %s\n", profileName, configPath)
+					}
+				},
+			})
 		},
 	}
 )
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configSearchPath is a directory searched for a config file.
+type configSearchPath struct {
+	Path string `json:"path"`
+	Used bool   `json:"used"`
+}
+
+// configPaths is the result of config path.
+type configPaths struct {
+	// Path is the config file used, if any
+	Path string `json:"path,omitempty"`
+	// Flag is true when the config file was given with --config
+	Flag        bool               `json:"flag"`
+	SearchPaths []configSearchPath `json:"search_paths"`
+}
+
+var (
+	configPathCmd = &cobra.Command{
+		Use:   "path",
+		Short: "Print the path of the config file used by mbctl",
+		Long: `Print the path of the config file used by mbctl, which is given with --config
+or else the first config.yaml found in the search paths.`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result := configPaths{Flag: rootOpts.configLocation != ""}
+			result.Path, _ = configFilePath()
+
+			dirs, err := configSearchDirs()
+			if err != nil {
+				return err
+			}
+			for _, dir := range dirs {
+				used := !result.Flag && filepath.Dir(viper.ConfigFileUsed()) == dir
+				result.SearchPaths = append(result.SearchPaths, configSearchPath{Path: dir, Used: used})
+			}
+
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{result.Path},
+				Table: func(w io.Writer) {
+					switch {
+					case result.Flag:
+						fmt.Fprintf(w, "Using %s given with --config\n", result.Path)
+					case result.Path == "":
+						fmt.Fprintln(w, "No config file found, create one with 'mbctl config init'")
+					default:
+						fmt.Fprintf(w, "Using %s\n", result.Path)
+					}
+
+					fmt.Fprintln(w, "\nSEARCH PATH\tUSED")
+					for _, searchPath := range result.SearchPaths {
+						fmt.Fprintf(w, "%s\t%t\n", searchPath.Path, searchPath.Used)
+					}
+				},
+			})
+		},
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configPathCmd)
+}
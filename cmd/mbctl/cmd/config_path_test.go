@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPathCmd(t *testing.T) {
+	defer resetOutput()
+	defer func(location string, searchPaths []string) {
+		rootOpts.configLocation = location
+		configSearchPaths = searchPaths
+	}(rootOpts.configLocation, configSearchPaths)
+
+	dir, err := ioutil.TempDir("", "mbctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the default search paths are kept first, the config file is only found in dir
+	configSearchPaths = append(configSearchPaths[:len(configSearchPaths):len(configSearchPaths)], dir)
+	viper.AddConfigPath(dir)
+	dirs, err := configSearchDirs()
+	require.NoError(t, err)
+
+	for _, dir := range dirs[:len(dirs)-1] {
+		if _, err := os.Stat(filepath.Join(dir, "config.yaml")); err == nil {
+			t.Skipf("a config file exists in %s", dir)
+		}
+	}
+
+	searchPaths := func(used string) []configSearchPath {
+		var paths []configSearchPath
+		for _, dir := range dirs {
+			paths = append(paths, configSearchPath{Path: dir, Used: dir == used})
+		}
+		return paths
+	}
+	configPath := func(args ...string) configPaths {
+		out, err := executeCommand(t, append(args, "config", "path", "--output", "json")...)
+		require.NoError(t, err)
+
+		var paths configPaths
+		require.NoError(t, json.Unmarshal([]byte(out), &paths))
+		return paths
+	}
+
+	t.Run("no config file", func(t *testing.T) {
+		rootOpts.configLocation = ""
+
+		out, err := executeCommand(t, "config", "path", "--output", "table")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No config file found, create one with 'mbctl config init'\n")
+		assert.Equal(t, configPaths{SearchPaths: searchPaths("")}, configPath())
+	})
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("apikey: key\napisecret: secret\n"), 0600))
+
+	t.Run("found in search path", func(t *testing.T) {
+		rootOpts.configLocation = ""
+
+		out, err := executeCommand(t, "config", "path", "--output", "table")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Using "+path+"\n")
+		assert.Equal(t, configPaths{Path: path, SearchPaths: searchPaths(dir)}, configPath())
+	})
+
+	t.Run("given with --config", func(t *testing.T) {
+		flagPath := filepath.Join(dir, "other.yaml")
+		require.NoError(t, ioutil.WriteFile(flagPath, []byte("apikey: key\napisecret: secret\n"), 0600))
+
+		out, err := executeCommand(t, "--config", flagPath, "config", "path", "--output", "table")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Using "+flagPath+" given with --config\n")
+		assert.Equal(t, configPaths{Path: flagPath, Flag: true, SearchPaths: searchPaths("")}, configPath("--config", flagPath))
+	})
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// configSetting is the result of the commands reading or writing a single config value.
+type configSetting struct {
+	Key     string      `json:"key"`
+	Value   interface{} `json:"value"`
+	Path    string      `json:"path,omitempty"`
+	Profile string      `json:"profile,omitempty"`
+}
+
+var (
+	configSetCmd = &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a value in the config file",
+		Long: `Set a value in the config file, which is created if --config is given and the file does not exist.
+With --profile or MAGICBELL_PROFILE, the value is set in that profile, which is created if needed.
+
+The keys are apikey, apisecret, baseurl, timeout (a duration such as 10s), skipvalidation (true or false)
+and current_profile. The API key and secret may be references such as env:MY_SECRET_VAR, see 'mbctl config init'.`,
+		Example: `mbctl config set apisecret env:MAGICBELL_API_SECRET
+mbctl config set timeout 10s --profile production`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := findConfigKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			var value interface{} = args[1]
+			if key.parse != nil {
+				if value, err = key.parse(args[1]); err != nil {
+					return fmt.Errorf("invalid value for %s: %w", key.FileKey, err)
+				}
+			}
+
+			configPath, err := configFilePath()
+			if err != nil {
+				return err
+			}
+			doc, err := readConfigFile(configPath)
+			if err != nil {
+				return err
+			}
+
+			profileName := requestedProfile()
+			if profileName == "" {
+				doc = setConfigValue(doc, value, key.FileKey)
+			} else if key.Name == currentProfileKey {
+				return fmt.Errorf("%s cannot be set in a profile", currentProfileKey)
+			} else {
+				doc = setConfigValue(doc, value, profilesKey, profileName, key.FileKey)
+			}
+
+			if err := writeConfigFile(configPath, doc); err != nil {
+				return err
+			}
+
+			if key.Name == "APISecret" {
+				value = redactSecret(args[1])
+			}
+			result := configSetting{Key: key.FileKey, Value: value, Path: configPath, Profile: profileName}
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{key.FileKey},
+				Table: func(w io.Writer) {
+					if profileName == "" {
+						fmt.Fprintf(w, "Set %s in %s\n", key.FileKey, configPath)
+					} else {
+						fmt.Fprintf(w, "Set %s of profile %s in %s\n", key.FileKey, profileName, configPath)
+					}
+				},
+			})
+		},
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configSetCmd)
+}
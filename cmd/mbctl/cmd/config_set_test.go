@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetProfileFlag clears the --profile flag, which keeps its value between runs of rootCmd.
+func resetProfileFlag() {
+	flag := rootCmd.Flag("profile")
+	_ = flag.Value.Set("")
+	flag.Changed = false
+}
+
+func TestConfigSetCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		file     string
+		wantErr  string
+	}{
+		{
+			name:     "top level",
+			args:     []string{"config", "set", "timeout", "10s"},
+			expected: "Set timeout in %s\n",
+			file:     "apikey: key\napisecret: secret\nprofiles:\n  staging:\n    apikey: staging-key\ntimeout: 10s\n",
+		},
+		{
+			name:     "existing top level value",
+			args:     []string{"config", "set", "apikey", "new-key"},
+			expected: "Set apikey in %s\n",
+			file:     "apikey: new-key\napisecret: secret\nprofiles:\n  staging:\n    apikey: staging-key\n",
+		},
+		{
+			name:     "current profile",
+			args:     []string{"config", "set", "current_profile", "staging"},
+			expected: "Set current_profile in %s\n",
+			file:     "apikey: key\napisecret: secret\nprofiles:\n  staging:\n    apikey: staging-key\ncurrent_profile: staging\n",
+		},
+		{
+			name:     "existing profile",
+			args:     []string{"config", "set", "apisecret", "staging-secret", "--profile", "staging"},
+			expected: "Set apisecret of profile staging in %s\n",
+			file:     "apikey: key\napisecret: secret\nprofiles:\n  staging:\n    apikey: staging-key\n    apisecret: staging-secret\n",
+		},
+		{
+			name:     "new profile",
+			args:     []string{"config", "set", "baseurl", "https://example.com", "--profile", "production"},
+			expected: "Set baseurl of profile production in %s\n",
+			file: "apikey: key\napisecret: secret\nprofiles:\n  staging:\n    apikey: staging-key\n" +
+				"  production:\n    baseurl: https://example.com\n",
+		},
+		{
+			name:    "current profile in a profile",
+			args:    []string{"config", "set", "current_profile", "staging", "--profile", "staging"},
+			wantErr: "current_profile cannot be set in a profile",
+		},
+		{
+			name:    "invalid value",
+			args:    []string{"config", "set", "timeout", "soon"},
+			wantErr: `invalid value for timeout: time: invalid duration "soon"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer resetProfileFlag()
+
+			dir, err := ioutil.TempDir("", "mbctl")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			configPath := filepath.Join(dir, "config.yaml")
+			original := "apikey: key\napisecret: secret\nprofiles:\n  staging:\n    apikey: staging-key\n"
+			require.NoError(t, ioutil.WriteFile(configPath, []byte(original), 0600))
+
+			out, err := executeCommand(t, append([]string{"--config", configPath}, test.args...)...)
+
+			data, readErr := ioutil.ReadFile(configPath)
+			require.NoError(t, readErr)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				assert.Equal(t, original, string(data))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf(test.expected, configPath), out)
+			assert.Equal(t, test.file, string(data))
+		})
+	}
+}
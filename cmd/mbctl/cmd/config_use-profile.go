@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	configUseProfileCmd = &cobra.Command{
+		Use:         "use-profile <name>",
+		Short:       "Set the profile used when --profile is not given",
+		Example:     "mbctl config use-profile production",
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath, err := configFilePath()
+			if err != nil {
+				return err
+			}
+			doc, err := readConfigFile(configPath)
+			if err != nil {
+				return err
+			}
+
+			profiles, _ := configValue(doc, profilesKey)
+			profilesDoc, _ := profiles.(yaml.MapSlice)
+			var names []string
+			for _, item := range profilesDoc {
+				names = append(names, fmt.Sprint(item.Key))
+			}
+			if _, ok := configValue(profilesDoc, args[0]); !ok {
+				sort.Strings(names)
+				return fmt.Errorf("profile %q not found in %s, expected one of: %s", args[0], configPath, strings.Join(names, ", "))
+			}
+
+			if err := writeConfigFile(configPath, setConfigValue(doc, args[0], currentProfileKey)); err != nil {
+				return err
+			}
+
+			result := configFile{Path: configPath, Profile: args[0]}
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{args[0]},
+				Table: func(w io.Writer) { fmt.Fprintf(w, "Using profile %s from %s\n", args[0], configPath) },
+			})
+		},
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configUseProfileCmd)
+}
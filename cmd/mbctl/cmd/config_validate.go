@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configValidation is the result of config validate.
+type configValidation struct {
+	Profile string `json:"profile,omitempty"`
+	BaseURL string `json:"baseurl"`
+	Valid   bool   `json:"valid"`
+}
+
+var (
+	configValidateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Check the API key and secret with a request to MagicBell which changes nothing",
+		Long: `Check the API key and secret with a request to MagicBell which changes nothing.
+The command fails if they are missing or incorrect.`,
+		Example: `mbctl config validate --profile production`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := client.VerifyCredentials(cmd.Context()); err != nil {
+				return fmt.Errorf("invalid credentials: %w", err)
+			}
+
+			result := configValidation{Profile: profile, BaseURL: viper.GetString("BaseURL"), Valid: true}
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{result.BaseURL},
+				Table: func(w io.Writer) { fmt.Fprintf(w, "The API key and secret are valid for %s\n", result.BaseURL) },
+			})
+		},
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+// configView is the config used by mbctl, with the keys of the config file.
+type configView struct {
+	Path           string `json:"path,omitempty"`
+	Profile        string `json:"profile,omitempty"`
+	APIKey         string `json:"apikey"`
+	APISecret      string `json:"apisecret"`
+	BaseURL        string `json:"baseurl"`
+	Timeout        string `json:"timeout"`
+	SkipValidation bool   `json:"skipvalidation"`
+}
+
+// redactSecret hides a secret, keeping its last characters to tell secrets apart when it is long enough.
+// Secret references are kept as they do not contain the secret itself.
+func redactSecret(secret string) string {
+	for _, prefix := range []string{magicbell.SecretRefFile, magicbell.SecretRefEnv, magicbell.SecretRefExec} {
+		if strings.HasPrefix(secret, prefix) {
+			return secret
+		}
+	}
+
+	switch {
+	case secret == "":
+		return ""
+	case len(secret) < 16:
+		return "********"
+	default:
+		return "********" + secret[len(secret)-4:]
+	}
+}
+
+var (
+	configViewCmd = &cobra.Command{
+		Use:   "view",
+		Short: "Print the config used by mbctl, with the API secret redacted",
+		Long: `Print the config used by mbctl, from the flags, environment variables, selected profile
+and config file, with the API secret redacted.`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configErr != nil {
+				// the values are printed anyway, to help fixing the config
+				logrus.Warn(configErr)
+			}
+
+			view := configView{Profile: profile}
+			view.Path, _ = configFilePath()
+			for _, key := range configKeys {
+				value := configKeyValue(key)
+				switch key.Name {
+				case "APIKey":
+					view.APIKey = value.(string)
+				case "APISecret":
+					view.APISecret = redactSecret(value.(string))
+				case "BaseURL":
+					view.BaseURL = value.(string)
+				case "Timeout":
+					view.Timeout = value.(string)
+				case "SkipValidation":
+					view.SkipValidation = value.(bool)
+				}
+			}
+
+			return printResult(commandResult{
+				Value: view,
+				IDs:   []string{view.Path},
+				Table: func(w io.Writer) {
+					fmt.Fprintln(w, "KEY\tVALUE")
+					fmt.Fprintf(w, "path\t%s\n", view.Path)
+					fmt.Fprintf(w, "profile\t%s\n", view.Profile)
+					fmt.Fprintf(w, "apikey\t%s\n", view.APIKey)
+					fmt.Fprintf(w, "apisecret\t%s\n", view.APISecret)
+					fmt.Fprintf(w, "baseurl\t%s\n", view.BaseURL)
+					fmt.Fprintf(w, "timeout\t%s\n", view.Timeout)
+					fmt.Fprintf(w, "skipvalidation\t%t\n", view.SkipValidation)
+				},
+			})
+		},
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configViewCmd)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		expected string
+	}{
+		{name: "empty", secret: "", expected: ""},
+		{name: "short", secret: "abc", expected: "********"},
+		{name: "just too short", secret: "0123456789abcde", expected: "********"},
+		{name: "long enough", secret: "0123456789abcdef", expected: "********cdef"},
+		{name: "long", secret: "sk_live_0123456789abcdefghij", expected: "********ghij"},
+		{name: "file reference", secret: "file:/run/secrets/magicbell", expected: "file:/run/secrets/magicbell"},
+		{name: "env reference", secret: "env:MAGICBELL_API_SECRET", expected: "env:MAGICBELL_API_SECRET"},
+		{name: "exec reference", secret: "exec:pass show magicbell", expected: "exec:pass show magicbell"},
+		{name: "prefix is case sensitive", secret: "ENV:MAGICBELL_API_SECRET", expected: "********CRET"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, redactSecret(test.secret))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+// customAttributesOptions are the flags of the commands which set custom attributes.
+type customAttributesOptions struct {
+	CustomAttributes []string // Key=Value or Key:=JSON
+	JSON             string   // JSON object or @file.json
+}
+
+func (o *customAttributesOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&o.CustomAttributes, "custom-attribute", nil, "A custom attribute in the Key=Value format, or Key:=JSON for "+
+		"numbers, booleans, lists and objects. Dotted keys are nested, e.g. order.id=123")
+	cmd.Flags().StringVar(&o.JSON, "custom-attributes-json", "", "The custom attributes as a JSON object, or @file.json to read them "+
+		"from a file. The --custom-attribute flags are merged into them")
+}
+
+// customAttributes returns the custom attributes given with --custom-attributes-json and --custom-attribute,
+// or nil if there are none.
+func (o *customAttributesOptions) customAttributes() (magicbell.CustomAttributes, error) {
+	if o.JSON == "" {
+		return parseCustomAttributes(o.CustomAttributes)
+	}
+
+	data := []byte(o.JSON)
+	if strings.HasPrefix(o.JSON, "@") {
+		var err error
+		if data, err = ioutil.ReadFile(strings.TrimPrefix(o.JSON, "@")); err != nil {
+			return nil, fmt.Errorf("unable to read custom attributes: %w", err)
+		}
+	}
+
+	var attrs magicbell.CustomAttributes
+	if err := decodeJSON(data, &attrs); err != nil {
+		return nil, fmt.Errorf("invalid --custom-attributes-json: %w", err)
+	} else if attrs == nil {
+		return nil, fmt.Errorf("--custom-attributes-json must be a JSON object")
+	}
+	if err := addCustomAttributes(attrs, o.CustomAttributes); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// parseCustomAttributes parses the custom attributes given with --custom-attribute flags, see addCustomAttributes.
+func parseCustomAttributes(rawAttrs []string) (magicbell.CustomAttributes, error) {
+	if rawAttrs == nil {
+		return nil, nil
+	}
+
+	attrs := magicbell.CustomAttributes{}
+	if err := addCustomAttributes(attrs, rawAttrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// addCustomAttributes sets the Key=Value pairs in attrs. Values given as Key:=Value are decoded as JSON, and dotted
+// keys such as order.id set the id in the order object, which is created if needed.
+func addCustomAttributes(attrs magicbell.CustomAttributes, rawAttrs []string) error {
+	for _, rawAttr := range rawAttrs {
+		i := strings.Index(rawAttr, "=")
+		if i < 0 {
+			return fmt.Errorf("expected '=' in custom attribute, got %s", rawAttr)
+		}
+
+		key := rawAttr[:i]
+		var value interface{} = rawAttr[i+1:]
+		if strings.HasSuffix(key, ":") {
+			key = strings.TrimSuffix(key, ":")
+			if err := decodeJSON([]byte(rawAttr[i+1:]), &value); err != nil {
+				return fmt.Errorf("invalid JSON value for custom attribute %s: %w", key, err)
+			}
+		}
+
+		if err := setCustomAttribute(attrs, strings.Split(key, "."), value); err != nil {
+			return fmt.Errorf("invalid custom attribute %s: %w", rawAttr, err)
+		}
+	}
+	return nil
+}
+
+// setCustomAttribute sets the value at the path of keys in attrs, creating the missing objects.
+func setCustomAttribute(attrs map[string]interface{}, keys []string, value interface{}) error {
+	if keys[0] == "" {
+		return fmt.Errorf("empty key")
+	}
+	if len(keys) == 1 {
+		attrs[keys[0]] = value
+		return nil
+	}
+
+	if attrs[keys[0]] == nil {
+		attrs[keys[0]] = map[string]interface{}{}
+	}
+	object, ok := attrs[keys[0]].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s is not an object", keys[0])
+	}
+	return setCustomAttribute(object, keys[1:], value)
+}
+
+// decodeJSON decodes data into v, keeping numbers such as ids as they are written.
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return fmt.Errorf("unexpected data after the JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+func TestParseCustomAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawAttrs []string
+		expected magicbell.CustomAttributes
+		err      string
+	}{
+		{
+			name:     "none",
+			rawAttrs: nil,
+			expected: nil,
+		},
+		{
+			name:     "strings",
+			rawAttrs: []string{"plan=pro", "greeting=a=b", "empty="},
+			expected: magicbell.CustomAttributes{"plan": "pro", "greeting": "a=b", "empty": ""},
+		},
+		{
+			name:     "typed values",
+			rawAttrs: []string{"seats:=12", "trial:=false", "tags:=[\"a\",\"b\"]", "order:={\"id\":1}", "note:=null"},
+			expected: magicbell.CustomAttributes{
+				"seats": json.Number("12"),
+				"trial": false,
+				"tags":  []interface{}{"a", "b"},
+				"order": map[string]interface{}{"id": json.Number("1")},
+				"note":  nil,
+			},
+		},
+		{
+			name:     "nested keys",
+			rawAttrs: []string{"order.id=123", "order.total:=9.5", "order.customer.name=Hana"},
+			expected: magicbell.CustomAttributes{"order": map[string]interface{}{
+				"id":       "123",
+				"total":    json.Number("9.5"),
+				"customer": map[string]interface{}{"name": "Hana"},
+			}},
+		},
+		{
+			name:     "nested key in a typed object",
+			rawAttrs: []string{"order:={\"id\":1}", "order.total=10"},
+			expected: magicbell.CustomAttributes{"order": map[string]interface{}{"id": json.Number("1"), "total": "10"}},
+		},
+		{
+			name:     "later values replace earlier ones",
+			rawAttrs: []string{"plan=free", "plan=pro"},
+			expected: magicbell.CustomAttributes{"plan": "pro"},
+		},
+		{
+			name:     "nested key under a value",
+			rawAttrs: []string{"a=1", "a.b=2"},
+			err:      "invalid custom attribute a.b=2: a is not an object",
+		},
+		{
+			name:     "missing equal sign",
+			rawAttrs: []string{"plan"},
+			err:      "expected '=' in custom attribute, got plan",
+		},
+		{
+			name:     "empty key",
+			rawAttrs: []string{"=pro"},
+			err:      "invalid custom attribute =pro: empty key",
+		},
+		{
+			name:     "empty nested key",
+			rawAttrs: []string{"order..id=1"},
+			err:      "invalid custom attribute order..id=1: empty key",
+		},
+		{
+			name:     "invalid typed value",
+			rawAttrs: []string{"seats:=twelve"},
+			err:      "invalid JSON value for custom attribute seats: invalid character 'w' in literal true (expecting 'r')",
+		},
+		{
+			name:     "trailing data after typed value",
+			rawAttrs: []string{"seats:=12 13"},
+			err:      "invalid JSON value for custom attribute seats: unexpected data after the JSON value",
+		},
+		{
+			name:     "empty typed value",
+			rawAttrs: []string{"seats:="},
+			err:      "invalid JSON value for custom attribute seats: EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attrs, err := parseCustomAttributes(test.rawAttrs)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, attrs)
+		})
+	}
+}
+
+func TestCustomAttributesOptions_CustomAttributes(t *testing.T) {
+	f, err := ioutil.TempFile("", "attrs*.json")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"plan": "pro", "order": {"id": 1}}`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	tests := []struct {
+		name     string
+		opts     customAttributesOptions
+		expected magicbell.CustomAttributes
+		err      string
+	}{
+		{
+			name:     "json merged with flags",
+			opts:     customAttributesOptions{JSON: `{"plan": "free", "seats": 2}`, CustomAttributes: []string{"plan=pro"}},
+			expected: magicbell.CustomAttributes{"plan": "pro", "seats": json.Number("2")},
+		},
+		{
+			name:     "json file",
+			opts:     customAttributesOptions{JSON: "@" + f.Name(), CustomAttributes: []string{"order.total:=10"}},
+			expected: magicbell.CustomAttributes{"plan": "pro", "order": map[string]interface{}{"id": json.Number("1"), "total": json.Number("10")}},
+		},
+		{
+			name: "json not an object",
+			opts: customAttributesOptions{JSON: `null`},
+			err:  "--custom-attributes-json must be a JSON object",
+		},
+		{
+			name: "invalid json",
+			opts: customAttributesOptions{JSON: `{"plan":`},
+			err:  "invalid --custom-attributes-json: unexpected EOF",
+		},
+		{
+			name: "missing file",
+			opts: customAttributesOptions{JSON: "@does-not-exist.json"},
+			err:  "unable to read custom attributes: open does-not-exist.json: no such file or directory",
+		},
+		{
+			name: "flag conflicting with json",
+			opts: customAttributesOptions{JSON: `{"a": 1}`, CustomAttributes: []string{"a.b=2"}},
+			err:  "invalid custom attribute a.b=2: a is not an object",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attrs, err := test.opts.customAttributes()
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, attrs)
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+const (
+	doctorOK      = "ok"
+	doctorWarning = "warning"
+	doctorFailed  = "failed"
+	doctorSkipped = "skipped"
+
+	// maxClockSkew is the clock difference with MagicBell reported as a warning
+	maxClockSkew = 30 * time.Second
+	// minCertificateValidity is the remaining validity of the TLS certificate reported as a warning
+	minCertificateValidity = 14 * 24 * time.Hour
+)
+
+// errorCodeExplanations explain what to do about the APIErrorCodes returned when checking the config and credentials.
+var errorCodeExplanations = map[magicbell.APIErrorCode]string{
+	magicbell.APIErrorCodeAPIKeyNotProvided: "No API key is configured. Set it with 'mbctl config set apikey <key>', " +
+		"--api-key or MAGICBELL_API_KEY.",
+	magicbell.APIErrorCodeIncorrectAPIKey: "The API key is not the key of any MagicBell project. Copy it again from " +
+		"the settings of your project, and check that the right profile is used with 'mbctl config view'.",
+	magicbell.APIErrorCodeAPISecretNotProvided: "No API secret is configured. Set it with 'mbctl config set apisecret <secret>', " +
+		"--api-secret or MAGICBELL_API_SECRET.",
+	magicbell.APIErrorCodeAPISecretIsIncorrect: "The API key is valid but the API secret is not its secret. Check that both " +
+		"come from the same MagicBell project, or copy the secret again if it was rotated.",
+	magicbell.APIErrorCodeForbidden: "The credentials are valid but are not allowed to make this request. " +
+		"Check the plan and permissions of the MagicBell project.",
+	magicbell.APIErrorCodeNeitherUserHMACNorAPISecretProvided: "Requests must be authenticated with the API secret " +
+		"or a user HMAC. Configure the API secret.",
+	magicbell.APIErrorCodeUserEmailNotProvided: "Requests authenticated with a user HMAC must also send the user's email.",
+	magicbell.APIErrorCodeParamMissing:         "A required setting is missing, set it with 'mbctl config set'.",
+	magicbell.APIErrorCodeParamInvalid: "A setting is invalid, for example the base URL must be like " +
+		"https://api.magicbell.io and the timeout like 10s. Fix it with 'mbctl config set'.",
+}
+
+// explainError returns the message of err followed by the explanations of its APIErrorCodes, if any.
+func explainError(err error) string {
+	var codes []magicbell.APIErrorCode
+	switch e := err.(type) {
+	case magicbell.APIErrors:
+		for _, apiErr := range e {
+			codes = append(codes, apiErr.Code)
+		}
+	case magicbell.NotFoundError:
+		for _, apiErr := range e.Errors {
+			codes = append(codes, apiErr.Code)
+		}
+	case magicbell.ValidationErrors:
+		for _, fieldErr := range e {
+			codes = append(codes, fieldErr.Code)
+		}
+	}
+
+	messages := []string{err.Error()}
+	explained := map[magicbell.APIErrorCode]bool{}
+	for _, code := range codes {
+		if explanation, ok := errorCodeExplanations[code]; ok && !explained[code] {
+			messages = append(messages, explanation)
+			explained[code] = true
+		}
+	}
+	return strings.Join(messages, ". ")
+}
+
+// doctorCheck is the result of one of the checks of mbctl doctor.
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// doctor runs the checks in order, skipping the checks which depend on a failed one.
+type doctor struct {
+	checks []doctorCheck
+	// baseURL is the parsed Config.BaseURL
+	baseURL *url.URL
+	// resp is the response to a request to the base URL, checked for TLS and the clock
+	resp *http.Response
+	// elapsed is how long the request to the base URL took
+	elapsed time.Duration
+}
+
+func (d *doctor) add(name, status, format string, args ...interface{}) {
+	d.checks = append(d.checks, doctorCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+}
+
+// failed returns the number of failed checks.
+func (d *doctor) failed() int {
+	var failed int
+	for _, check := range d.checks {
+		if check.Status == doctorFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// checkConfigFile checks the config file and the selected profile.
+func (d *doctor) checkConfigFile() {
+	configPath, err := configFilePath()
+	switch {
+	case rootOpts.configLocation != "":
+		if _, statErr := os.Stat(rootOpts.configLocation); statErr != nil {
+			d.add("config file", doctorFailed, "%s given with --config cannot be read: %s", configPath, statErr)
+			return
+		}
+		d.add("config file", doctorOK, "using %s given with --config", configPath)
+	case err != nil:
+		dirs, _ := configSearchDirs()
+		d.add("config file", doctorWarning, "no config file found in %s, only flags and environment variables are used. "+
+			"Create one with 'mbctl config init'", strings.Join(dirs, " or "))
+	default:
+		d.add("config file", doctorOK, "using %s", configPath)
+	}
+
+	if configErr != nil {
+		d.add("config", doctorFailed, "%s", configErr)
+	} else if profile != "" {
+		d.add("profile", doctorOK, "using profile %s", profile)
+	}
+}
+
+// checkConfig checks that the credentials are set and the base URL is valid.
+func (d *doctor) checkConfig() {
+	if err := clientConfig.Validate(); err != nil {
+		d.add("settings", doctorFailed, "%s", explainError(err))
+	} else {
+		d.add("settings", doctorOK, "the API key and secret are set")
+	}
+
+	baseURL, err := url.Parse(clientConfig.BaseURL)
+	if err != nil || baseURL.Host == "" {
+		d.add("base url", doctorFailed, "%q is not a valid URL, set one like https://api.magicbell.io with 'mbctl config set baseurl'", clientConfig.BaseURL)
+		return
+	}
+	d.baseURL = baseURL
+}
+
+// checkDNS checks that the host of the base URL resolves.
+func (d *doctor) checkDNS(ctx context.Context) {
+	host := d.baseURL.Hostname()
+	if net.ParseIP(host) != nil {
+		d.add("dns", doctorOK, "%s is an IP address", host)
+		return
+	}
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		d.add("dns", doctorFailed, "unable to resolve %s: %s. Check the base URL, your DNS settings and network connection", host, err)
+		d.baseURL = nil
+		return
+	}
+	d.add("dns", doctorOK, "%s resolves to %s", host, strings.Join(addrs, ", "))
+}
+
+// checkReachability makes a request to the base URL, without credentials.
+func (d *doctor) checkReachability(ctx context.Context) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL.String(), nil)
+	if err != nil {
+		d.add("reachability", doctorFailed, "unable to create a request to %s: %s", d.baseURL, err)
+		return
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	d.elapsed = time.Since(start)
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	switch {
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostname), errors.As(err, &invalid):
+		d.add("reachability", doctorOK, "connected to %s", d.baseURL.Host)
+		d.add("tls", doctorFailed, "the TLS certificate of %s is not trusted: %s. A proxy may be intercepting HTTPS "+
+			"traffic, or the system certificates may be outdated", d.baseURL.Host, err)
+	case err != nil:
+		d.add("reachability", doctorFailed, "unable to reach %s: %s. Check your network, firewall and proxy settings", d.baseURL, err)
+	default:
+		resp.Body.Close()
+		d.resp = resp
+		d.add("reachability", doctorOK, "%s responded with HTTP %d in %s", d.baseURL, resp.StatusCode, d.elapsed.Round(time.Millisecond))
+	}
+}
+
+// checkTLS checks the TLS connection of the response to the base URL.
+func (d *doctor) checkTLS() {
+	if d.resp.TLS == nil {
+		d.add("tls", doctorWarning, "the base URL does not use HTTPS, so the API secret is sent in plain text")
+		return
+	}
+
+	versions := map[uint16]string{tls.VersionTLS10: "1.0", tls.VersionTLS11: "1.1", tls.VersionTLS12: "1.2", tls.VersionTLS13: "1.3"}
+	version := versions[d.resp.TLS.Version]
+	if version == "" {
+		version = fmt.Sprintf("0x%04x", d.resp.TLS.Version)
+	}
+	if len(d.resp.TLS.PeerCertificates) == 0 {
+		d.add("tls", doctorOK, "TLS %s", version)
+		return
+	}
+
+	cert := d.resp.TLS.PeerCertificates[0]
+	if validity := time.Until(cert.NotAfter); validity < minCertificateValidity {
+		d.add("tls", doctorWarning, "TLS %s, the certificate of %s expires on %s", version, d.baseURL.Host, cert.NotAfter.Format(time.RFC3339))
+		return
+	}
+	d.add("tls", doctorOK, "TLS %s, certificate issued by %s valid until %s", version, cert.Issuer.CommonName, cert.NotAfter.Format(time.RFC3339))
+}
+
+// checkClock compares the local clock with the Date header of the response to the base URL.
+func (d *doctor) checkClock() {
+	date, err := http.ParseTime(d.resp.Header.Get("Date"))
+	if err != nil {
+		d.add("clock", doctorSkipped, "the response has no valid Date header to compare the local clock with")
+		return
+	}
+
+	// the Date header is truncated to the second and was set during the request
+	skew := time.Since(date) - d.elapsed/2
+	direction := "ahead of"
+	if skew < 0 {
+		skew, direction = -skew, "behind"
+	}
+	skew = skew.Round(time.Second)
+
+	if skew > maxClockSkew {
+		d.add("clock", doctorWarning, "the local clock is %s %s MagicBell, so times such as notification "+
+			"timestamps will be off. Synchronize it with NTP", skew, direction)
+		return
+	}
+	d.add("clock", doctorOK, "the local clock is within %s of MagicBell", maxClockSkew)
+}
+
+// checkCredentials checks the API key and secret with MagicBell.
+func (d *doctor) checkCredentials(ctx context.Context) {
+	if err := client.VerifyCredentials(ctx); magicbell.IsNotFoundError(err) {
+		d.add("credentials", doctorFailed, "%s. The base URL %s does not seem to be the MagicBell API", err, d.baseURL)
+		return
+	} else if err != nil {
+		d.add("credentials", doctorFailed, "%s", explainError(err))
+		return
+	}
+	d.add("credentials", doctorOK, "the API key and secret are valid")
+}
+
+var (
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems with the config, network connection and credentials",
+		Long: `Diagnose problems with the config, network connection and credentials, checking in order:
+
+  config file   which config file and profile are used
+  settings      the API key and secret are set and the base URL is valid
+  dns           the host of the base URL resolves
+  reachability  the base URL responds
+  tls           the TLS certificate is trusted and not about to expire
+  clock         the local clock is close to MagicBell's
+  credentials   MagicBell accepts the API key and secret
+
+The command fails if any check failed.`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{configOptionalAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d := &doctor{}
+			d.checkConfigFile()
+			d.checkConfig()
+
+			// each network check may take as long as an API request
+			timeout := 5 * time.Second
+			if clientConfig.Timeout != nil && *clientConfig.Timeout > 0 {
+				timeout = *clientConfig.Timeout
+			}
+			if d.baseURL != nil {
+				ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+				defer cancel()
+				d.checkDNS(ctx)
+			}
+			if d.baseURL != nil {
+				ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+				defer cancel()
+				d.checkReachability(ctx)
+			}
+			if d.resp != nil {
+				d.checkTLS()
+				d.checkClock()
+			}
+			if d.resp != nil && d.failed() == 0 {
+				d.checkCredentials(cmd.Context())
+			} else {
+				d.add("credentials", doctorSkipped, "fix the failed checks first")
+			}
+
+			result := commandResult{Value: d.checks, Items: make([]interface{}, len(d.checks))}
+			for i, check := range d.checks {
+				result.Items[i] = check
+				result.IDs = append(result.IDs, check.Name)
+			}
+			result.Table = func(w io.Writer) {
+				fmt.Fprintln(w, "CHECK\tSTATUS\tMESSAGE")
+				for _, check := range d.checks {
+					fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, check.Status, check.Message)
+				}
+			}
+			if err := printResult(result); err != nil {
+				return err
+			}
+
+			if failed := d.failed(); failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(d.checks))
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
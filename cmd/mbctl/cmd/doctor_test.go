@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+func TestExplainError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("connection refused"),
+			expected: "connection refused",
+		},
+		{
+			name: "api errors",
+			err: magicbell.APIErrors{
+				{Code: magicbell.APIErrorCodeAPISecretIsIncorrect, Message: "Secret is incorrect"},
+				{Code: magicbell.APIErrorCodeAPISecretIsIncorrect, Message: "Secret is still incorrect"},
+			},
+			expected: "Secret is incorrect. " + errorCodeExplanations[magicbell.APIErrorCodeAPISecretIsIncorrect],
+		},
+		{
+			name:     "unknown code",
+			err:      magicbell.APIErrors{{Code: "rate_limited", Message: "Too many requests"}},
+			expected: "Too many requests",
+		},
+		{
+			name:     "not found",
+			err:      magicbell.NotFoundError{Errors: magicbell.APIErrors{{Code: magicbell.APIErrorCodeForbidden, Message: "Not allowed"}}},
+			expected: "HTTP 404 Not Found: Not allowed. " + errorCodeExplanations[magicbell.APIErrorCodeForbidden],
+		},
+		{
+			name: "validation errors",
+			err: magicbell.ValidationErrors{
+				{Field: "APIKey", Code: magicbell.APIErrorCodeParamMissing, Message: "is required"},
+				{Field: "BaseURL", Code: magicbell.APIErrorCodeParamInvalid, Message: "must be an absolute URL"},
+			},
+			expected: "APIKey is required. " + errorCodeExplanations[magicbell.APIErrorCodeParamMissing] + ". " +
+				errorCodeExplanations[magicbell.APIErrorCodeParamInvalid],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, explainError(test.err))
+		})
+	}
+}
+
+func TestDoctor_CheckClock(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    func() string
+		elapsed time.Duration
+		status  string
+		message string
+	}{
+		{
+			name:    "in sync",
+			date:    func() string { return time.Now().UTC().Format(http.TimeFormat) },
+			status:  doctorOK,
+			message: "the local clock is within 30s of MagicBell",
+		},
+		{
+			name:    "ahead",
+			date:    func() string { return time.Now().Add(-2 * time.Minute).UTC().Format(http.TimeFormat) },
+			status:  doctorWarning,
+			message: "the local clock is 2m0s ahead of MagicBell",
+		},
+		{
+			name:    "behind",
+			date:    func() string { return time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat) },
+			status:  doctorWarning,
+			message: "the local clock is 2m0s behind MagicBell",
+		},
+		{
+			name:    "slow request",
+			date:    func() string { return time.Now().Add(-40 * time.Second).UTC().Format(http.TimeFormat) },
+			elapsed: 30 * time.Second,
+			status:  doctorOK,
+			message: "the local clock is within 30s of MagicBell",
+		},
+		{
+			name:    "no date",
+			date:    func() string { return "" },
+			status:  doctorSkipped,
+			message: "the response has no valid Date header to compare the local clock with",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &doctor{resp: &http.Response{Header: http.Header{"Date": {test.date()}}}, elapsed: test.elapsed}
+			d.checkClock()
+
+			require.Len(t, d.checks, 1)
+			assert.Equal(t, "clock", d.checks[0].Name)
+			assert.Equal(t, test.status, d.checks[0].Status)
+			assert.True(t, strings.HasPrefix(d.checks[0].Message, test.message), d.checks[0].Message)
+		})
+	}
+}
+
+func TestDoctor_CheckReachability(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	tlsSrv := httptest.NewTLSServer(handler)
+	defer tlsSrv.Close()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	closedSrv := httptest.NewServer(handler)
+	closedSrv.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		trust   bool
+		checks  []string
+	}{
+		{name: "trusted tls", baseURL: tlsSrv.URL, trust: true, checks: []string{"reachability: ok", "tls: ok"}},
+		{name: "untrusted tls", baseURL: tlsSrv.URL, checks: []string{"reachability: ok", "tls: failed"}},
+		{name: "plain http", baseURL: srv.URL, checks: []string{"reachability: ok", "tls: warning"}},
+		{name: "unreachable", baseURL: closedSrv.URL, checks: []string{"reachability: failed"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.trust {
+				defaultClient := http.DefaultClient
+				http.DefaultClient = tlsSrv.Client()
+				defer func() { http.DefaultClient = defaultClient }()
+			}
+
+			baseURL, err := url.Parse(test.baseURL)
+			require.NoError(t, err)
+			d := &doctor{baseURL: baseURL}
+			d.checkReachability(context.Background())
+			if d.resp != nil {
+				d.checkTLS()
+			}
+
+			var checks []string
+			for _, check := range d.checks {
+				checks = append(checks, check.Name+": "+check.Status)
+			}
+			assert.Equal(t, test.checks, checks)
+		})
+	}
+}
+
+func TestDoctorCmd(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users" {
+			_, _ = w.Write([]byte(`{"users":[]}`))
+		}
+	})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	tlsSrv := httptest.NewTLSServer(handler)
+	defer tlsSrv.Close()
+	closedSrv := httptest.NewServer(handler)
+	closedSrv.Close()
+	rejectingSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"api_secret_is_incorrect","message":"Secret is incorrect"}]}`))
+		}
+	}))
+	defer rejectingSrv.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		checks  []string
+		wantErr string
+	}{
+		{
+			name:    "healthy",
+			baseURL: srv.URL,
+			checks:  []string{"config file: ok", "settings: ok", "dns: ok", "reachability: ok", "tls: warning", "clock: ok", "credentials: ok"},
+		},
+		{
+			name:    "invalid credentials",
+			baseURL: rejectingSrv.URL,
+			checks:  []string{"config file: ok", "settings: ok", "dns: ok", "reachability: ok", "tls: warning", "clock: ok", "credentials: failed"},
+			wantErr: "1 of 7 checks failed",
+		},
+		{
+			name:    "untrusted tls skips the clock and credentials",
+			baseURL: tlsSrv.URL,
+			checks:  []string{"config file: ok", "settings: ok", "dns: ok", "reachability: ok", "tls: failed", "credentials: skipped"},
+			wantErr: "1 of 6 checks failed",
+		},
+		{
+			name:    "unreachable skips tls, the clock and credentials",
+			baseURL: closedSrv.URL,
+			checks:  []string{"config file: ok", "settings: ok", "dns: ok", "reachability: failed", "credentials: skipped"},
+			wantErr: "1 of 5 checks failed",
+		},
+		{
+			name:    "invalid base url skips the network checks",
+			baseURL: "api.magicbell.io",
+			checks:  []string{"config file: ok", "settings: failed", "base url: failed", "credentials: skipped"},
+			wantErr: "2 of 4 checks failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer resetOutput()
+
+			out, err := runCommand(t, test.baseURL, "doctor", "--output", "json")
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var results []doctorCheck
+			require.NoError(t, json.Unmarshal([]byte(out), &results))
+			var checks []string
+			for _, check := range results {
+				checks = append(checks, check.Name+": "+check.Status)
+			}
+			assert.Equal(t, test.checks, checks)
+		})
+	}
+}
@@ -1,12 +1,76 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
 
 var notificationsCmd = &cobra.Command{
 	Use:   "notifications",
 	Short: "Group of commands related to Notifications",
 }
 
+// addUserFlag adds the required --user flag of the commands acting on a user's notifications.
+func addUserFlag(cmd *cobra.Command, user *string) {
+	cmd.Flags().StringVar(user, "user", "", "The user whose notifications to use, as "+userIdentityHelp)
+	_ = cmd.MarkFlagRequired("user")
+}
+
+// newNotificationChangeCmd returns a command which applies change to every notification id given as argument.
+// The action describes the change in the printed results, for example archived.
+func newNotificationChangeCmd(use, short, action string, change func(cmd *cobra.Command, user magicbell.UserIdentity, notificationID string) error) *cobra.Command {
+	var rawUser string
+
+	cmd := &cobra.Command{
+		Use:     use + " <notification-id>...",
+		Short:   short,
+		Example: fmt.Sprintf("mbctl notifications %s --user hana@magicbell.io ffffff66-ea4f-4da2-afc6-84148b51657a", use),
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := parseUserIdentity(rawUser)
+			if err != nil {
+				return err
+			}
+
+			var changes []changeResult
+			for _, notificationID := range args {
+				if err := change(cmd, user, notificationID); err != nil {
+					// print what was changed before the error
+					if len(changes) > 0 {
+						_ = printResult(changeResults(changes))
+					}
+					return fmt.Errorf("notification %s: %w", notificationID, err)
+				}
+				changes = append(changes, changeResult{ID: notificationID, Action: action})
+			}
+			return printResult(changeResults(changes))
+		},
+	}
+	addUserFlag(cmd, &rawUser)
+	return cmd
+}
+
+// notificationsTable writes the notifications as a table.
+func notificationsTable(w io.Writer, notifications ...magicbell.Notification) {
+	fmt.Fprintln(w, "ID\tTITLE\tCATEGORY\tSENT\tSEEN\tREAD\tARCHIVED")
+	for _, n := range notifications {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			n.ID, n.Title, n.Category, formatTimestamp(n.SentAt), formatTimestamp(n.SeenAt), formatTimestamp(n.ReadAt), formatTimestamp(n.ArchivedAt))
+	}
+}
+
+func formatTimestamp(ts *magicbell.Timestamp) string {
+	if ts == nil || ts.IsZero() {
+		return "-"
+	}
+	return ts.Local().Format(time.RFC822)
+}
+
 func init() {
 	rootCmd.AddCommand(notificationsCmd)
 }
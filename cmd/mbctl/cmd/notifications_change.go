@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+func init() {
+	notificationsCmd.AddCommand(
+		newNotificationChangeCmd("read", "Mark a user's notifications as read", "read",
+			func(cmd *cobra.Command, user magicbell.UserIdentity, notificationID string) error {
+				return client.Notifications.MarkRead(cmd.Context(), user, notificationID)
+			}),
+		newNotificationChangeCmd("unread", "Mark a user's notifications as unread", "unread",
+			func(cmd *cobra.Command, user magicbell.UserIdentity, notificationID string) error {
+				return client.Notifications.MarkUnread(cmd.Context(), user, notificationID)
+			}),
+		newNotificationChangeCmd("archive", "Archive a user's notifications", "archived",
+			func(cmd *cobra.Command, user magicbell.UserIdentity, notificationID string) error {
+				return client.Notifications.Archive(cmd.Context(), user, notificationID)
+			}),
+		newNotificationChangeCmd("delete", "Delete a user's notifications", "deleted",
+			func(cmd *cobra.Command, user magicbell.UserIdentity, notificationID string) error {
+				return client.Notifications.Delete(cmd.Context(), user, notificationID)
+			}),
+	)
+}
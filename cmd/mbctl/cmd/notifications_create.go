@@ -2,21 +2,25 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	magicbell "github.com/tizz98/magicbell-go"
 )
 
 type notificationsCreateOptions struct {
-	Title            string
-	Recipients       []string
-	Content          string
-	ActionURL        string
-	Category         string
-	CustomAttributes []string // Key=Value
+	Title         string
+	Recipients    []string
+	Content       string
+	ContentFile   string
+	ContentFormat string
+	ActionURL     string
+	Category      string
+	customAttributesOptions
 }
 
 func (o notificationsCreateOptions) getNotificationRecipients() (recipients []magicbell.NotificationRecipient) {
@@ -33,22 +37,40 @@ func (o notificationsCreateOptions) getNotificationRecipients() (recipients []ma
 	return
 }
 
-func (o notificationsCreateOptions) getCustomAttributes() magicbell.CustomAttributes {
-	if o.CustomAttributes == nil {
-		return nil
-	}
+// getContent returns the notification content and its format, reading the content from
+// ContentFile when set. The format is inferred from the file extension unless given explicitly.
+func (o notificationsCreateOptions) getContent() (string, magicbell.ContentFormat, error) {
+	content := o.Content
+	rawFormat := o.ContentFormat
 
-	attrs := magicbell.CustomAttributes{}
-	for _, rawAttr := range o.CustomAttributes {
-		values := strings.SplitN(rawAttr, "=", 2)
-		if len(values) != 2 {
-			panic(fmt.Sprintf("expected '=' in custom attribute, got %s", rawAttr))
+	if o.ContentFile != "" {
+		if o.Content != "" {
+			return "", "", fmt.Errorf("only one of --content or --content-file may be specified")
 		}
 
-		attrs[values[0]] = values[1]
+		data, err := ioutil.ReadFile(o.ContentFile)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to read content file: %w", err)
+		}
+		content = string(data)
+
+		if rawFormat == "" {
+			switch strings.ToLower(filepath.Ext(o.ContentFile)) {
+			case ".md", ".markdown":
+				rawFormat = string(magicbell.ContentFormatMarkdown)
+			case ".html", ".htm":
+				rawFormat = string(magicbell.ContentFormatHTML)
+			case ".txt":
+				rawFormat = string(magicbell.ContentFormatPlain)
+			}
+		}
 	}
 
-	return attrs
+	format, err := magicbell.ParseContentFormat(rawFormat)
+	if err != nil {
+		return "", "", err
+	}
+	return content, format, nil
 }
 
 var (
@@ -57,11 +79,21 @@ var (
 		Aliases: []string{"send"},
 		Short:   "Send a notification to one or more users.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			content, contentFormat, err := notificationCreateOpts.getContent()
+			if err != nil {
+				return err
+			}
+			customAttributes, err := notificationCreateOpts.customAttributes()
+			if err != nil {
+				return err
+			}
+
 			notification, err := api.CreateNotificationC(cmd.Context(), magicbell.CreateNotificationRequest{
 				Title:            notificationCreateOpts.Title,
 				Recipients:       notificationCreateOpts.getNotificationRecipients(),
-				Content:          notificationCreateOpts.Content,
-				CustomAttributes: notificationCreateOpts.getCustomAttributes(),
+				Content:          content,
+				ContentFormat:    contentFormat,
+				CustomAttributes: customAttributes,
 				ActionURL:        notificationCreateOpts.ActionURL,
 				Category:         notificationCreateOpts.Category,
 			})
@@ -69,8 +101,14 @@ var (
 				return err
 			}
 
-			logrus.Infof("Created notification %s", notification.ID)
-			return nil
+			return printResult(commandResult{
+				Value: notification,
+				IDs:   []string{notification.ID},
+				Table: func(w io.Writer) {
+					fmt.Fprintln(w, "ID")
+					fmt.Fprintln(w, notification.ID)
+				},
+			})
 		},
 	}
 
@@ -81,9 +119,11 @@ func init() {
 	notificationsCreateCmd.Flags().StringVar(&notificationCreateOpts.Title, "title", "", "The title of the notification")
 	notificationsCreateCmd.Flags().StringSliceVar(&notificationCreateOpts.Recipients, "recipients", nil, "Comma separated emails or external ids of users who should receive this notification")
 	notificationsCreateCmd.Flags().StringVar(&notificationCreateOpts.Content, "content", "", "The content of the notification")
+	notificationsCreateCmd.Flags().StringVar(&notificationCreateOpts.ContentFile, "content-file", "", "A file to read the content of the notification from")
+	notificationsCreateCmd.Flags().StringVar(&notificationCreateOpts.ContentFormat, "content-format", "", "The format of the content: plain, markdown or html. Inferred from the --content-file extension if not set")
 	notificationsCreateCmd.Flags().StringVar(&notificationCreateOpts.ActionURL, "action-url", "", "The URL to redirect to when clicking the notification")
 	notificationsCreateCmd.Flags().StringVar(&notificationCreateOpts.Category, "category", "", "The category of the notification")
-	notificationsCreateCmd.Flags().StringArrayVar(&notificationCreateOpts.CustomAttributes, "custom-attribute", nil, "A list of custom attributes in the Key=Value format")
+	notificationCreateOpts.customAttributesOptions.addFlags(notificationsCreateCmd)
 
 	_ = notificationsCreateCmd.MarkFlagRequired("title")
 	_ = notificationsCreateCmd.MarkFlagRequired("recipients")
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	notificationsGetCmd = &cobra.Command{
+		Use:     "get <notification-id>",
+		Short:   "Print one of a user's notifications",
+		Example: "mbctl notifications get --user hana@magicbell.io ffffff66-ea4f-4da2-afc6-84148b51657a",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := parseUserIdentity(notificationsGetUser)
+			if err != nil {
+				return err
+			}
+
+			notification, err := client.Notifications.Get(cmd.Context(), user, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printResult(commandResult{
+				Value: notification,
+				IDs:   []string{notification.ID},
+				Table: func(w io.Writer) { notificationsTable(w, *notification) },
+			})
+		},
+	}
+	notificationsGetUser string
+)
+
+func init() {
+	addUserFlag(notificationsGetCmd, &notificationsGetUser)
+
+	notificationsCmd.AddCommand(notificationsGetCmd)
+}
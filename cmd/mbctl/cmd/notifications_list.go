@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+type notificationsListOptions struct {
+	User     string
+	Unread   bool
+	Unseen   bool
+	Archived bool
+	Category string
+	Topic    string
+	Page     int
+	PerPage  int
+}
+
+func (o notificationsListOptions) request() magicbell.ListNotificationsRequest {
+	req := magicbell.ListNotificationsRequest{
+		Category: o.Category,
+		Topic:    o.Topic,
+		Page:     o.Page,
+		PerPage:  o.PerPage,
+	}
+	if o.Unread {
+		req.Read = newBool(false)
+	}
+	if o.Unseen {
+		req.Seen = newBool(false)
+	}
+	if o.Archived {
+		req.Archived = newBool(true)
+	}
+	return req
+}
+
+var (
+	notificationsListCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List a user's notifications, newest first",
+		Example: "mbctl notifications list --user hana@magicbell.io --unread --category billing",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := parseUserIdentity(notificationsListOpts.User)
+			if err != nil {
+				return err
+			}
+
+			list, err := client.Notifications.List(cmd.Context(), user, notificationsListOpts.request())
+			if err != nil {
+				return err
+			}
+
+			result := commandResult{
+				Value: list,
+				Items: make([]interface{}, len(list.Notifications)),
+				Table: func(w io.Writer) {
+					notificationsTable(w, list.Notifications...)
+					fmt.Fprintf(w, "\nPage %d of %d, %d notifications (%d unread, %d unseen)\n", list.CurrentPage, list.TotalPages, list.Total, list.UnreadCount, list.UnseenCount)
+				},
+			}
+			for i, notification := range list.Notifications {
+				result.Items[i] = notification
+				result.IDs = append(result.IDs, notification.ID)
+			}
+			return printResult(result)
+		},
+	}
+
+	notificationsListOpts = &notificationsListOptions{}
+)
+
+func newBool(b bool) *bool { return &b }
+
+func init() {
+	addUserFlag(notificationsListCmd, &notificationsListOpts.User)
+	notificationsListCmd.Flags().BoolVar(&notificationsListOpts.Unread, "unread", false, "Only list unread notifications")
+	notificationsListCmd.Flags().BoolVar(&notificationsListOpts.Unseen, "unseen", false, "Only list unseen notifications")
+	notificationsListCmd.Flags().BoolVar(&notificationsListOpts.Archived, "archived", false, "Only list archived notifications")
+	notificationsListCmd.Flags().StringVar(&notificationsListOpts.Category, "category", "", "Only list notifications of this category")
+	notificationsListCmd.Flags().StringVar(&notificationsListOpts.Topic, "topic", "", "Only list notifications of this topic")
+	notificationsListCmd.Flags().IntVar(&notificationsListOpts.Page, "page", 0, "The page of notifications to list, starting at 1")
+	notificationsListCmd.Flags().IntVar(&notificationsListOpts.PerPage, "per-page", 0, "The number of notifications per page")
+
+	notificationsCmd.AddCommand(notificationsListCmd)
+}
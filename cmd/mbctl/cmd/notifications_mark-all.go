@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	notificationsMarkAllReadCmd = &cobra.Command{
+		Use:     "mark-all-read",
+		Short:   "Mark all of a user's notifications as read",
+		Example: "mbctl notifications mark-all-read --user hana@magicbell.io",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := parseUserIdentity(notificationsMarkAllReadUser)
+			if err != nil {
+				return err
+			}
+			if err := client.Notifications.MarkAllRead(cmd.Context(), user); err != nil {
+				return err
+			}
+
+			return printResult(changeResults([]changeResult{{ID: notificationsMarkAllReadUser, Action: "marked all read"}}))
+		},
+	}
+	notificationsMarkAllReadUser string
+
+	notificationsMarkAllSeenCmd = &cobra.Command{
+		Use:     "mark-all-seen",
+		Short:   "Mark all of a user's notifications as seen",
+		Example: "mbctl notifications mark-all-seen --user hana@magicbell.io",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user, err := parseUserIdentity(notificationsMarkAllSeenUser)
+			if err != nil {
+				return err
+			}
+			if err := client.Notifications.MarkAllSeen(cmd.Context(), user); err != nil {
+				return err
+			}
+
+			return printResult(changeResults([]changeResult{{ID: notificationsMarkAllSeenUser, Action: "marked all seen"}}))
+		},
+	}
+	notificationsMarkAllSeenUser string
+)
+
+func init() {
+	addUserFlag(notificationsMarkAllReadCmd, &notificationsMarkAllReadUser)
+	addUserFlag(notificationsMarkAllSeenCmd, &notificationsMarkAllSeenUser)
+
+	notificationsCmd.AddCommand(notificationsMarkAllReadCmd, notificationsMarkAllSeenCmd)
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+const sendBatchTemplateName = "send-batch"
+
+type notificationsSendBatchOptions struct {
+	batchOptions
+	notificationsCreateOptions
+}
+
+// recipient returns the recipient of the row. Columns prefixed with custom_attributes. are stored on the recipient.
+func (o *notificationsSendBatchOptions) recipient(row batchRow) (magicbell.NotificationRecipient, error) {
+	recipient := magicbell.NotificationRecipient{
+		Email:      stringValue(row.Values["email"]),
+		ExternalID: stringValue(row.Values["external_id"]),
+		FirstName:  stringValue(row.Values["first_name"]),
+		LastName:   stringValue(row.Values["last_name"]),
+	}
+
+	switch phoneNumbers := row.Values["phone_numbers"].(type) {
+	case string:
+		for _, phoneNumber := range strings.Split(phoneNumbers, ",") {
+			if phoneNumber = strings.TrimSpace(phoneNumber); phoneNumber != "" {
+				recipient.PhoneNumbers = append(recipient.PhoneNumbers, phoneNumber)
+			}
+		}
+	case []interface{}:
+		for _, phoneNumber := range phoneNumbers {
+			recipient.PhoneNumbers = append(recipient.PhoneNumbers, stringValue(phoneNumber))
+		}
+	}
+
+	var err error
+	recipient.CustomAttributes, err = row.customAttributes()
+	return recipient, err
+}
+
+// templateData returns the data the templates are rendered with for the row,
+// columns prefixed with custom_attributes. are grouped under custom_attributes.
+func (o *notificationsSendBatchOptions) templateData(row batchRow) (magicbell.CustomAttributes, error) {
+	data := magicbell.CustomAttributes{}
+	for key, value := range row.Values {
+		if !strings.HasPrefix(key, customAttributesPrefix) {
+			data[key] = value
+			continue
+		}
+
+		attrs, ok := data["custom_attributes"].(map[string]interface{})
+		if !ok {
+			attrs = map[string]interface{}{}
+			data["custom_attributes"] = attrs
+		}
+		name, value, err := customAttributeColumn(key, value)
+		if err != nil {
+			return nil, err
+		}
+		attrs[name] = value
+	}
+	return data, nil
+}
+
+// notification renders the notification of the row for its recipient. Only the columns prefixed with
+// custom_attributes. are attached to the notification, the other columns such as the recipient's email
+// are only available to the templates.
+func (o *notificationsSendBatchOptions) notification(templates *magicbell.TemplateRegistry, row batchRow, recipient magicbell.NotificationRecipient) (magicbell.CreateNotificationRequest, error) {
+	data, err := o.templateData(row)
+	if err != nil {
+		return magicbell.CreateNotificationRequest{}, err
+	}
+
+	req, err := templates.Render(sendBatchTemplateName, []magicbell.NotificationRecipient{recipient}, data)
+	if err != nil {
+		return magicbell.CreateNotificationRequest{}, err
+	}
+	req.CustomAttributes = recipient.CustomAttributes
+	return req, nil
+}
+
+// send sends the notification rendered for the row to its recipient.
+func (o *notificationsSendBatchOptions) send(ctx context.Context, row batchRow) batchRowResult {
+	recipient, err := o.recipient(row)
+	result := batchRowResult{Row: row.Number, Email: recipient.Email, ExternalID: recipient.ExternalID}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	req, err := o.notification(client.Templates, row, recipient)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	notification, err := client.Notifications.Create(ctx, req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.ID = notification.ID
+	result.Action = "sent"
+	return result
+}
+
+var (
+	notificationsSendBatchCmd = &cobra.Command{
+		Use:   "send-batch",
+		Short: "Send a notification to every row of a CSV or JSONL file",
+		Long: `Send a notification to every row of a CSV or JSONL file.
+
+The email, external_id, first_name, last_name and phone_numbers columns are the recipient of the row,
+use --column to rename the columns of your file to these fields. Columns named custom_attributes.<name>
+are stored as custom attributes of the recipient, and are available as {{.custom_attributes.<name>}} in templates.
+
+The title, content and action URL are Go templates rendered with the columns of each row,
+for example --title 'Hi {{.first_name}}'. Only the custom_attributes.<name> columns are attached to each
+notification as its custom attributes, the other columns are not sent along with the notification.
+
+With --results, the result of every row is written to a JSONL file. Run the command again with --resume
+to only send the notifications of the rows which failed or were not sent yet.`,
+		Example: `mbctl notifications send-batch --file recipients.csv \
+  --column "E-mail=email" --column "Plan=custom_attributes.plan" \
+  --title 'Your {{.custom_attributes.plan}} plan renews soon' --content-file renewal.md \
+  --concurrency 8 --rate 20 --results results.jsonl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, contentFormat, err := notificationsSendBatchOpts.getContent()
+			if err != nil {
+				return err
+			}
+			if err := client.Templates.Register(sendBatchTemplateName, magicbell.NotificationTemplate{
+				Title:         notificationsSendBatchOpts.Title,
+				Content:       content,
+				ContentFormat: contentFormat,
+				ActionURL:     notificationsSendBatchOpts.ActionURL,
+				Category:      notificationsSendBatchOpts.Category,
+			}); err != nil {
+				return err
+			}
+
+			rows, err := notificationsSendBatchOpts.readRows()
+			if err != nil {
+				return err
+			}
+
+			results, succeeded, err := notificationsSendBatchOpts.openResults()
+			if err != nil {
+				return err
+			}
+
+			pending := rows[:0:0]
+			for _, row := range rows {
+				if !succeeded[row.Number] {
+					pending = append(pending, row)
+				}
+			}
+
+			notificationsSendBatchOpts.runBatch(cmd.Context(), pending, func(ctx context.Context, row batchRow) error {
+				return results.add(notificationsSendBatchOpts.send(ctx, row))
+			})
+			if err := results.close(); err != nil {
+				return err
+			}
+
+			summary := results.summary(len(rows) - len(pending))
+			if err := printResult(summary); err != nil {
+				return err
+			}
+			return summary.Value.(*batchSummary).err()
+		},
+	}
+
+	notificationsSendBatchOpts = &notificationsSendBatchOptions{}
+)
+
+func init() {
+	notificationsSendBatchOpts.batchOptions.addFlags(notificationsSendBatchCmd)
+	notificationsSendBatchCmd.Flags().StringVar(&notificationsSendBatchOpts.Title, "title", "", "The title template of the notifications")
+	notificationsSendBatchCmd.Flags().StringVar(&notificationsSendBatchOpts.Content, "content", "", "The content template of the notifications")
+	notificationsSendBatchCmd.Flags().StringVar(&notificationsSendBatchOpts.ContentFile, "content-file", "", "A file to read the content template of the notifications from")
+	notificationsSendBatchCmd.Flags().StringVar(&notificationsSendBatchOpts.ContentFormat, "content-format", "", "The format of the content: plain, markdown or html. Inferred from the --content-file extension if not set")
+	notificationsSendBatchCmd.Flags().StringVar(&notificationsSendBatchOpts.ActionURL, "action-url", "", "The template of the URL to redirect to when clicking the notifications")
+	notificationsSendBatchCmd.Flags().StringVar(&notificationsSendBatchOpts.Category, "category", "", "The category of the notifications")
+
+	_ = notificationsSendBatchCmd.MarkFlagRequired("title")
+
+	notificationsCmd.AddCommand(notificationsSendBatchCmd)
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputJSONL    = "jsonl"
+	outputID       = "id"
+	outputTemplate = "template"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML, outputJSONL, outputID, outputTemplate}
+
+// commandResult is the result of a command, with what is needed to print it in every output format.
+// The json, yaml, jsonl and template outputs use the JSON field names of the library structs.
+type commandResult struct {
+	// Value is printed by the json and yaml outputs
+	Value interface{}
+	// Items are printed one per line by the jsonl and template outputs, Value is used when nil
+	Items []interface{}
+	// IDs are printed one per line by the id output
+	IDs []string
+	// Table writes the result for humans, columns are separated by tabs
+	Table func(w io.Writer)
+}
+
+// changeResult is the result of a command changing a resource which has nothing else to print.
+type changeResult struct {
+	// ID identifies the changed resource
+	ID string `json:"id"`
+	// Action is what was done to the resource, for example archived
+	Action string `json:"action"`
+}
+
+// changeResults returns the commandResult of the changes.
+func changeResults(changes []changeResult) commandResult {
+	result := commandResult{Value: changes, Items: make([]interface{}, len(changes))}
+	for i, change := range changes {
+		result.Items[i] = change
+		result.IDs = append(result.IDs, change.ID)
+	}
+	result.Table = func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tACTION")
+		for _, change := range changes {
+			fmt.Fprintf(w, "%s\t%s\n", change.ID, change.Action)
+		}
+	}
+	return result
+}
+
+// validateOutput checks the --output and --template flags.
+func validateOutput() error {
+	if rootOpts.template != "" {
+		if rootOpts.output != outputTable && rootOpts.output != outputTemplate {
+			return fmt.Errorf("--template cannot be used with --output %s", rootOpts.output)
+		}
+		rootOpts.output = outputTemplate
+	}
+
+	for _, format := range outputFormats {
+		if rootOpts.output == format {
+			if format == outputTemplate && rootOpts.template == "" {
+				return fmt.Errorf("--output template requires --template")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q, expected one of %s", rootOpts.output, strings.Join(outputFormats, ", "))
+}
+
+// printResult prints the result to stdout in the format chosen with --output.
+func printResult(result commandResult) error {
+	return writeResult(os.Stdout, rootOpts.output, rootOpts.template, result)
+}
+
+func writeResult(w io.Writer, format, tmpl string, result commandResult) error {
+	items := result.Items
+	if items == nil {
+		items = []interface{}{result.Value}
+	}
+
+	switch format {
+	case outputJSON:
+		data, err := json.MarshalIndent(result.Value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to serialize result: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case outputYAML:
+		data, err := marshalYAML(result.Value)
+		if err != nil {
+			return fmt.Errorf("unable to serialize result: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case outputJSONL:
+		encoder := json.NewEncoder(w)
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return fmt.Errorf("unable to serialize result: %w", err)
+			}
+		}
+		return nil
+	case outputID:
+		for _, id := range result.IDs {
+			if _, err := fmt.Fprintln(w, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputTemplate:
+		t, err := template.New("output").Option("missingkey=zero").Parse(tmpl)
+		if err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+		for _, item := range items {
+			value, err := jsonValue(item)
+			if err != nil {
+				return fmt.Errorf("unable to serialize result: %w", err)
+			}
+			if err := t.Execute(w, value); err != nil {
+				return fmt.Errorf("unable to execute --template: %w", err)
+			}
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		result.Table(tw)
+		return tw.Flush()
+	}
+}
+
+// jsonValue returns v as the generic value it is serialized to in JSON, so its fields use the JSON names.
+func jsonValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep numbers such as timestamps as they are written in JSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// marshalYAML serializes v to YAML through JSON, keeping the JSON field names and order.
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
+
+// decodeOrdered decodes the next JSON value, keeping the order of object keys with yaml.MapSlice.
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '[' {
+			list := []interface{}{}
+			for decoder.More() {
+				item, err := decodeOrdered(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			_, err := decoder.Token() // ]
+			return list, err
+		}
+
+		object := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err := decoder.Token() // }
+		return object, err
+	case json.Number:
+		if i, err := token.Int64(); err == nil {
+			return i, nil
+		}
+		return token.Float64()
+	default:
+		return token, nil
+	}
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type outputItem struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	SentAt    int64   `json:"sent_at"`
+	Score     float64 `json:"score"`
+	Recipient string  `json:"recipient,omitempty"`
+}
+
+var outputItems = []outputItem{
+	{ID: "b", Title: "Second", SentAt: 1612868462, Score: 0.5},
+	{ID: "a", Title: "First", SentAt: 1612868463, Score: 2},
+}
+
+func outputTestResult() commandResult {
+	result := commandResult{Value: outputItems}
+	for _, item := range outputItems {
+		result.Items = append(result.Items, item)
+		result.IDs = append(result.IDs, item.ID)
+	}
+	result.Table = func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tTITLE")
+		for _, item := range outputItems {
+			fmt.Fprintf(w, "%s\t%s\n", item.ID, item.Title)
+		}
+	}
+	return result
+}
+
+func resetOutput() {
+	rootOpts.output = outputTable
+	rootOpts.template = ""
+}
+
+func TestValidateOutput(t *testing.T) {
+	defer resetOutput()
+
+	tests := []struct {
+		name       string
+		output     string
+		template   string
+		wantOutput string
+		wantErr    string
+	}{
+		{name: "default", output: outputTable, wantOutput: outputTable},
+		{name: "json", output: outputJSON, wantOutput: outputJSON},
+		{name: "yaml", output: outputYAML, wantOutput: outputYAML},
+		{name: "jsonl", output: outputJSONL, wantOutput: outputJSONL},
+		{name: "id", output: outputID, wantOutput: outputID},
+		{name: "template without output", output: outputTable, template: "{{.id}}", wantOutput: outputTemplate},
+		{name: "template with output template", output: outputTemplate, template: "{{.id}}", wantOutput: outputTemplate},
+		{name: "output template without template", output: outputTemplate, wantErr: "--output template requires --template"},
+		{name: "template with other output", output: outputJSON, template: "{{.id}}", wantErr: "--template cannot be used with --output json"},
+		{
+			name:    "unknown format",
+			output:  "xml",
+			wantErr: `unknown output format "xml", expected one of table, json, yaml, jsonl, id, template`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootOpts.output = tt.output
+			rootOpts.template = tt.template
+
+			err := validateOutput()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOutput, rootOpts.output)
+		})
+	}
+}
+
+func TestWriteResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		template string
+		result   commandResult
+		want     string
+		wantErr  string
+	}{
+		{
+			name:   "table",
+			format: outputTable,
+			result: outputTestResult(),
+			want:   "ID  TITLE\nb   Second\na   First\n",
+		},
+		{
+			name:   "json",
+			format: outputJSON,
+			result: outputTestResult(),
+			want: `[
+  {
+    "id": "b",
+    "title": "Second",
+    "sent_at": 1612868462,
+    "score": 0.5
+  },
+  {
+    "id": "a",
+    "title": "First",
+    "sent_at": 1612868463,
+    "score": 2
+  }
+]
+`,
+		},
+		{
+			name:   "yaml keeps the json field order",
+			format: outputYAML,
+			result: outputTestResult(),
+			want: `- id: b
+  title: Second
+  sent_at: 1612868462
+  score: 0.5
+- id: a
+  title: First
+  sent_at: 1612868463
+  score: 2
+`,
+		},
+		{
+			name:   "jsonl",
+			format: outputJSONL,
+			result: outputTestResult(),
+			want: `{"id":"b","title":"Second","sent_at":1612868462,"score":0.5}
+{"id":"a","title":"First","sent_at":1612868463,"score":2}
+`,
+		},
+		{
+			name:   "jsonl without items prints the value",
+			format: outputJSONL,
+			result: commandResult{Value: outputItems[0]},
+			want:   `{"id":"b","title":"Second","sent_at":1612868462,"score":0.5}` + "\n",
+		},
+		{
+			name:   "id",
+			format: outputID,
+			result: outputTestResult(),
+			want:   "b\na\n",
+		},
+		{
+			name:     "template",
+			format:   outputTemplate,
+			template: "{{.id}} {{.title}} {{.sent_at}} {{.score}}",
+			result:   outputTestResult(),
+			want:     "b Second 1612868462 0.5\na First 1612868463 2\n",
+		},
+		{
+			name:     "template with missing key",
+			format:   outputTemplate,
+			template: "{{.id}}:{{.recipient}}",
+			result:   outputTestResult(),
+			want:     "b:<no value>\na:<no value>\n",
+		},
+		{
+			name:     "invalid template",
+			format:   outputTemplate,
+			template: "{{.id",
+			result:   outputTestResult(),
+			wantErr:  "invalid --template: template: output:1: unclosed action",
+		},
+		{
+			name:    "unserializable value",
+			format:  outputJSON,
+			result:  commandResult{Value: make(chan int)},
+			wantErr: "unable to serialize result: json: unsupported type: chan int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writeResult(&buf, tt.format, tt.template, tt.result)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{
+			name:  "struct uses json field names",
+			value: outputItems[0],
+			want: map[string]interface{}{
+				"id":      "b",
+				"title":   "Second",
+				"sent_at": json.Number("1612868462"),
+				"score":   json.Number("0.5"),
+			},
+		},
+		{name: "large integer is not rounded", value: int64(9007199254740993), want: json.Number("9007199254740993")},
+		{name: "string", value: "hello", want: "hello"},
+		{name: "nil", value: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := jsonValue(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{
+			name: "struct keeps field order",
+			value: struct {
+				Zebra string `json:"zebra"`
+				Apple string `json:"apple"`
+			}{Zebra: "z", Apple: "a"},
+			want: "zebra: z\napple: a\n",
+		},
+		{
+			name:  "map keys are sorted like json",
+			value: map[string]int{"b": 2, "a": 1},
+			want:  "a: 1\nb: 2\n",
+		},
+		{
+			name:  "numbers",
+			value: map[string]interface{}{"float": 1.5, "int": 1612868462, "large": int64(9007199254740993)},
+			want:  "float: 1.5\nint: 1612868462\nlarge: 9007199254740993\n",
+		},
+		{
+			name:  "nested",
+			value: map[string]interface{}{"list": []interface{}{"a", map[string]bool{"ok": true}}, "empty": []string{}},
+			want:  "empty: []\nlist:\n- a\n- ok: true\n",
+		},
+		{name: "null", value: nil, want: "null\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := marshalYAML(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestDecodeOrdered(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "object keeps key order",
+			json: `{"z": 1, "a": {"y": true, "b": null}}`,
+			want: yaml.MapSlice{
+				{Key: "z", Value: int64(1)},
+				{Key: "a", Value: yaml.MapSlice{{Key: "y", Value: true}, {Key: "b", Value: nil}}},
+			},
+		},
+		{name: "empty object", json: `{}`, want: yaml.MapSlice{}},
+		{name: "array", json: `[1, "two", 3.5]`, want: []interface{}{int64(1), "two", 3.5}},
+		{name: "empty array", json: `[]`, want: []interface{}{}},
+		{name: "integer", json: `9007199254740993`, want: int64(9007199254740993)},
+		{name: "float", json: `1e3`, want: float64(1000)},
+		{name: "string", json: `"hello"`, want: "hello"},
+		{name: "truncated", json: `{"a": [1`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(tt.json))
+			decoder.UseNumber()
+
+			value, err := decodeOrdered(decoder)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestOutputFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{name: "table", args: nil, want: "https://api.magicbell.io\n"},
+		{name: "json", args: []string{"--output", "json"}, want: "{\n  \"key\": \"baseurl\",\n  \"value\": \"https://api.magicbell.io\"\n}\n"},
+		{name: "yaml", args: []string{"-o", "yaml"}, want: "key: baseurl\nvalue: https://api.magicbell.io\n"},
+		{name: "jsonl", args: []string{"-o", "jsonl"}, want: `{"key":"baseurl","value":"https://api.magicbell.io"}` + "\n"},
+		{name: "id", args: []string{"-o", "id"}, want: "https://api.magicbell.io\n"},
+		{name: "template", args: []string{"--template", "{{.key}}={{.value}}"}, want: "baseurl=https://api.magicbell.io\n"},
+		{
+			name: "template with output template",
+			args: []string{"-o", "template", "--template", "{{.key}}"},
+			want: "baseurl\n",
+		},
+		{name: "output template without template", args: []string{"-o", "template"}, wantErr: "--output template requires --template"},
+		{
+			name:    "unknown format",
+			args:    []string{"-o", "xml"},
+			wantErr: `unknown output format "xml", expected one of table, json, yaml, jsonl, id, template`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetOutput()
+
+			out, err := runCommand(t, "https://api.magicbell.io", append([]string{"config", "get", "baseurl"}, tt.args...)...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -10,13 +11,19 @@ import (
 	"github.com/spf13/viper"
 
 	magicbell "github.com/tizz98/magicbell-go"
+	"github.com/tizz98/magicbell-go/logadapter"
 	"github.com/tizz98/magicbell-go/version"
 )
 
 type rootOptions struct {
 	verbose        bool
+	logBodies      bool
 	jsonLogging    bool
 	configLocation string
+	output         string
+	template       string
+	// allowExecSecrets runs the exec: secret references of a config file found in the current directory
+	allowExecSecrets bool
 }
 
 var (
@@ -25,19 +32,27 @@ var (
 		Short:   "Control MagicBell from the command line!",
 		Version: fmt.Sprintf("%s (%s)", version.BuildVersion, version.BuildCommit),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(); err != nil {
+				return err
+			}
+			// the flags are valid, so don't print the usage for errors returned by the command
+			cmd.SilenceUsage = true
+
 			// Read viper config
 			if rootOpts.configLocation != "" {
 				f, err := os.Open(rootOpts.configLocation)
-				if err != nil {
+				if os.IsNotExist(err) && cmd.Annotations[configOptionalAnnotation] != "" {
+					logrus.Debugf("config file %s not found, using default values", rootOpts.configLocation)
+				} else if err != nil {
 					return fmt.Errorf("unable to open config file %s: %w", rootOpts.configLocation, err)
-				}
-
-				if err := viper.ReadConfig(f); err != nil {
-					return fmt.Errorf("unable to read config file %s: %w", rootOpts.configLocation, err)
-				}
+				} else {
+					if err := viper.ReadConfig(f); err != nil {
+						return fmt.Errorf("unable to read config file %s: %w", rootOpts.configLocation, err)
+					}
 
-				if err := f.Close(); err != nil {
-					return fmt.Errorf("unable to close config file: %w", err)
+					if err := f.Close(); err != nil {
+						return fmt.Errorf("unable to close config file: %w", err)
+					}
 				}
 			} else {
 				if err := viper.ReadInConfig(); err != nil {
@@ -62,43 +77,96 @@ var (
 				})
 			}
 
+			// the commands which work without a valid config get the error in configErr instead
+			configOptional := cmd.Annotations[configOptionalAnnotation] != ""
+
+			name, err := applyProfile()
+			if err != nil && !configOptional {
+				return err
+			}
+			profile, configErr = name, err
+
 			timeout := viper.GetDuration("Timeout")
-			api = magicbell.New(magicbell.Config{
+			config := magicbell.Config{
 				APIKey:    viper.GetString("APIKey"),
 				APISecret: viper.GetString("APISecret"),
 				BaseURL:   viper.GetString("BaseURL"),
 				Timeout:   &timeout,
-			})
+
+				SkipValidation: viper.GetBool("SkipValidation"),
+			}
+			if resolved, err := resolveSecrets(config); err == nil {
+				config = resolved
+			} else if !configOptional {
+				return err
+			} else if configErr == nil {
+				configErr = err
+			}
+			clientConfig = config
+			if rootOpts.verbose {
+				// show the wire traffic along with the command logs
+				config.Logger = logadapter.Logrus(logrus.StandardLogger())
+				config.LogBodies = rootOpts.logBodies
+			}
+			client = magicbell.NewClient(magicbell.WithConfig(config))
+			api = client.API()
 
 			return nil
 		},
 	}
 
 	rootOpts = &rootOptions{}
-	// api should be used by all sub-commands
+	// client should be used by all sub-commands
+	client *magicbell.Client
+	// api is the compatibility layer over client, used by the older sub-commands
 	api magicbell.IAPI
+	// profile is the name of the config file profile in use, if any
+	profile string
+	// clientConfig is the config of client, without its Logger
+	clientConfig magicbell.Config
+	// configErr is the error reading the config, for the commands which work without a valid config
+	configErr error
+	// configSearchPaths are the directories searched for a config file when --config is not given, in order
+	configSearchPaths = []string{"$HOME/.config/magicbell", "."}
 )
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&rootOpts.verbose, "verbose", "v", false, "Enable verbose/debug logging")
+	rootCmd.PersistentFlags().BoolVar(&rootOpts.logBodies, "log-bodies", false, "Include HTTP request and response bodies in verbose logs")
 	rootCmd.PersistentFlags().BoolVar(&rootOpts.jsonLogging, "json", false, "Print logs in JSON")
+	rootCmd.PersistentFlags().StringVarP(&rootOpts.output, "output", "o", outputTable, "The format to print results in: "+strings.Join(outputFormats, ", "))
+	rootCmd.PersistentFlags().StringVar(&rootOpts.template, "template", "", "A Go template to print each result with, using the JSON field names, e.g. '{{.id}}'")
 	rootCmd.PersistentFlags().StringVarP(&rootOpts.configLocation, "config", "c", "", "Specify an explicit config location. Defaults to $CWD/config.yaml or ~/.config/magicbell/config.yaml")
+	rootCmd.PersistentFlags().BoolVar(&rootOpts.allowExecSecrets, "allow-exec-secrets", false, "Run the exec: secret references of a config file "+
+		"found in the current directory, see 'mbctl config --help'")
+	rootCmd.PersistentFlags().String("profile", "", "The config file profile to use instead of current_profile, see 'mbctl config --help'")
 
 	// magicbell.Config flags
 	rootCmd.PersistentFlags().String("api-key", "", "The MagicBell API key to use in requests")
 	rootCmd.PersistentFlags().String("api-secret", "", "The MagicBell API secret to use in requests")
 	rootCmd.PersistentFlags().String("base-url", "https://api.magicbell.io", "The MagicBell API URL to use instead of the default")
 	rootCmd.PersistentFlags().Duration("timeout", 5*time.Second, "How long to wait for HTTP requests to timeout")
+	rootCmd.PersistentFlags().Bool("skip-validation", false, "Send requests to the API without validating them first")
 
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
-	viper.AddConfigPath("$HOME/.config/magicbell")
-	viper.AddConfigPath(".")
+	for _, configPath := range configSearchPaths {
+		viper.AddConfigPath(configPath)
+	}
 
 	_ = viper.BindPFlag("APIKey", rootCmd.Flag("api-key"))
 	_ = viper.BindPFlag("APISecret", rootCmd.Flag("api-secret"))
 	_ = viper.BindPFlag("BaseURL", rootCmd.Flag("base-url"))
 	_ = viper.BindPFlag("Timeout", rootCmd.Flag("timeout"))
+	_ = viper.BindPFlag("SkipValidation", rootCmd.Flag("skip-validation"))
+	_ = viper.BindPFlag(currentProfileKey, rootCmd.Flag("profile"))
+
+	_ = viper.BindEnv("APIKey", magicbell.EnvAPIKey)
+	_ = viper.BindEnv("APISecret", magicbell.EnvAPISecret)
+	_ = viper.BindEnv("BaseURL", magicbell.EnvBaseURL)
+	_ = viper.BindEnv("Timeout", magicbell.EnvTimeout)
+	_ = viper.BindEnv("SkipValidation", magicbell.EnvSkipValidation)
+	_ = viper.BindEnv(currentProfileKey, envProfile)
 }
 
 // Execute runs the mbctl root command
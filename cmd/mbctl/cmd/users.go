@@ -1,12 +1,101 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
 
 var usersCmd = &cobra.Command{
 	Use:   "users",
 	Short: "Commands related to Users",
 }
 
+// userOptions are the flags of the commands which create or update a user.
+type userOptions struct {
+	ExternalID string
+	Email      string
+	FirstName  string
+	LastName   string
+	customAttributesOptions
+}
+
+func (o *userOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.ExternalID, "external-id", "", "The unique id of the user in your database")
+	cmd.Flags().StringVar(&o.Email, "email", "", "The user's email")
+	cmd.Flags().StringVar(&o.FirstName, "first-name", "", "The user's first name")
+	cmd.Flags().StringVar(&o.LastName, "last-name", "", "The user's last name")
+	o.customAttributesOptions.addFlags(cmd)
+}
+
+const (
+	// userIdentityHelp describes the users accepted by parseUserIdentity, for the help of the commands
+	userIdentityHelp = "an email, an external id, or a prefixed id like email:hana@magicbell.io or external_id:56780"
+	// userIDHelp describes the users accepted by resolveUserID, for the help of the commands
+	userIDHelp = userIdentityHelp + ", or their MagicBell id like id:ffffff66-ea4f-4da2-afc6-84148b51657a"
+)
+
+// parseUserIdentity returns the identity of a user given on the command line. Values containing an '@'
+// are emails, others are external ids, unless prefixed with email: or external_id:.
+func parseUserIdentity(user string) (magicbell.UserIdentity, error) {
+	switch {
+	case strings.HasPrefix(user, "email:"):
+		user = strings.TrimPrefix(user, "email:")
+		if user == "" {
+			return magicbell.UserIdentity{}, fmt.Errorf("missing email in user email:")
+		}
+		return magicbell.UserIdentity{Email: user}, nil
+	case strings.HasPrefix(user, "external_id:"):
+		user = strings.TrimPrefix(user, "external_id:")
+		if user == "" {
+			return magicbell.UserIdentity{}, fmt.Errorf("missing external id in user external_id:")
+		}
+		return magicbell.UserIdentity{ExternalID: user}, nil
+	case user == "":
+		return magicbell.UserIdentity{}, fmt.Errorf("missing user")
+	case strings.ContainsRune(user, '@'):
+		return magicbell.UserIdentity{Email: user}, nil
+	default:
+		return magicbell.UserIdentity{ExternalID: user}, nil
+	}
+}
+
+// resolveUserID returns the user id to use in API requests for a user given on the command line.
+// Users are read like parseUserIdentity does, and MagicBell ids are given with the id: prefix.
+func resolveUserID(user string) (string, error) {
+	if strings.HasPrefix(user, "id:") {
+		if user = strings.TrimPrefix(user, "id:"); user == "" {
+			return "", fmt.Errorf("missing id in user id:")
+		}
+		return user, nil
+	}
+
+	identity, err := parseUserIdentity(user)
+	if err != nil {
+		return "", err
+	}
+	if identity.Email != "" {
+		return magicbell.EmailUserID(identity.Email), nil
+	}
+	return magicbell.ExternalIDUserID(identity.ExternalID), nil
+}
+
+// userResult returns the commandResult of a user.
+func userResult(user *magicbell.User) commandResult {
+	return commandResult{
+		Value: user,
+		IDs:   []string{user.ID},
+		Table: func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tEMAIL\tEXTERNAL ID\tFIRST NAME\tLAST NAME")
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", user.ID, user.Email, user.ExternalID, user.FirstName, user.LastName)
+		},
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(usersCmd)
 }
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+var (
+	usersCreateCmd = &cobra.Command{
+		Use:     "create",
+		Short:   "Create a user in MagicBell",
+		Example: "mbctl users create --email hana@magicbell.io --first-name Hana --custom-attribute plan=enterprise",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			customAttributes, err := usersCreateOpts.customAttributes()
+			if err != nil {
+				return err
+			}
+
+			user, err := client.Users.Create(cmd.Context(), magicbell.CreateUserRequest{
+				ExternalID:       usersCreateOpts.ExternalID,
+				Email:            usersCreateOpts.Email,
+				FirstName:        usersCreateOpts.FirstName,
+				LastName:         usersCreateOpts.LastName,
+				CustomAttributes: customAttributes,
+			})
+			if err != nil {
+				return err
+			}
+
+			return printResult(userResult(user))
+		},
+	}
+
+	usersCreateOpts = &userOptions{}
+)
+
+func init() {
+	usersCreateOpts.addFlags(usersCreateCmd)
+
+	usersCmd.AddCommand(usersCreateCmd)
+}
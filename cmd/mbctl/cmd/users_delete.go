@@ -0,0 +1,29 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var usersDeleteCmd = &cobra.Command{
+	Use:   "delete <user>",
+	Short: "Delete a user and their notifications from MagicBell",
+	Long: `Delete a user and their notifications from MagicBell.
+
+The user is ` + userIDHelp + `.`,
+	Example: "mbctl users delete hana@magicbell.io",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userID, err := resolveUserID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := client.Users.Delete(cmd.Context(), userID); err != nil {
+			return err
+		}
+
+		return printResult(changeResults([]changeResult{{ID: userID, Action: "deleted"}}))
+	},
+}
+
+func init() {
+	usersCmd.AddCommand(usersDeleteCmd)
+}
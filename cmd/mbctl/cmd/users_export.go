@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+type usersExportOptions struct {
+	File    string
+	Format  string
+	PerPage int
+}
+
+// exportedUsers is the result of users export.
+type exportedUsers struct {
+	Path  string `json:"path"`
+	Users int    `json:"users"`
+}
+
+// format returns the format to export the users in, inferred from the file extension if not set.
+func (o *usersExportOptions) format() (string, error) {
+	format := o.Format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(o.File)) {
+		case ".csv":
+			format = batchFormatCSV
+		default:
+			format = batchFormatJSONL
+		}
+	}
+
+	if format != batchFormatCSV && format != batchFormatJSONL {
+		return "", fmt.Errorf("unknown export format %q, expected csv or jsonl", format)
+	}
+	return format, nil
+}
+
+// writeJSONL writes a user per line, with the same fields as the JSON of magicbell.User.
+func writeUsersJSONL(w io.Writer, users []magicbell.User) error {
+	encoder := json.NewEncoder(w)
+	for _, user := range users {
+		if err := encoder.Encode(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeUsersCSV writes a user per row, with a custom_attributes.<name> column for every custom attribute.
+// Custom attributes whose values would not be read back as the same strings by 'mbctl users import', such as
+// numbers, are written as JSON in a custom_attributes.<name>: column instead, so they keep their type.
+func writeUsersCSV(w io.Writer, users []magicbell.User) error {
+	// jsonAttrs are the custom attributes written as JSON
+	jsonAttrs := map[string]bool{}
+	for _, user := range users {
+		for name, value := range user.CustomAttributes {
+			s, ok := value.(string)
+			plain := ok && s != "" && !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[")
+			jsonAttrs[name] = jsonAttrs[name] || !plain
+		}
+	}
+	sortedAttrNames := make([]string, 0, len(jsonAttrs))
+	for name := range jsonAttrs {
+		sortedAttrNames = append(sortedAttrNames, name)
+	}
+	sort.Strings(sortedAttrNames)
+
+	header := []string{"id", "external_id", "email", "first_name", "last_name"}
+	for _, name := range sortedAttrNames {
+		column := customAttributesPrefix + name
+		if jsonAttrs[name] {
+			column += ":"
+		}
+		header = append(header, column)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, user := range users {
+		record := []string{user.ID, user.ExternalID, user.Email, user.FirstName, user.LastName}
+		for _, name := range sortedAttrNames {
+			value, ok := user.CustomAttributes[name]
+			if !ok || !jsonAttrs[name] {
+				record = append(record, stringValue(value))
+				continue
+			}
+
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("unable to encode custom attribute %s of user %s: %w", name, user.ID, err)
+			}
+			record = append(record, string(data))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+var (
+	usersExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Write all users to a CSV or JSONL file",
+		Long: `Write all users to a CSV or JSONL file, which can be imported again with 'mbctl users import'.
+
+The users are written to stdout unless --file is set. CSV files have a custom_attributes.<name> column for
+every custom attribute. Custom attributes which are not all plain strings, such as numbers or objects, are
+written as JSON in a custom_attributes.<name>: column instead, so they keep their type when imported again.`,
+		Example: `mbctl users export --file users.csv
+mbctl users export | jq .email`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := usersExportOpts.format()
+			if err != nil {
+				return err
+			}
+
+			var users []magicbell.User
+			for page := 1; ; page++ {
+				list, err := client.Users.List(cmd.Context(), magicbell.ListUsersRequest{Page: page, PerPage: usersExportOpts.PerPage})
+				if err != nil {
+					return err
+				}
+				users = append(users, list.Users...)
+				logrus.Debugf("Fetched page %d of %d of users", page, list.TotalPages)
+
+				if len(list.Users) == 0 || page >= list.TotalPages {
+					break
+				}
+			}
+
+			w := io.Writer(os.Stdout)
+			if usersExportOpts.File != "" {
+				f, err := os.Create(usersExportOpts.File)
+				if err != nil {
+					return fmt.Errorf("unable to create export file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if format == batchFormatCSV {
+				err = writeUsersCSV(w, users)
+			} else {
+				err = writeUsersJSONL(w, users)
+			}
+			if err != nil {
+				return fmt.Errorf("unable to write users: %w", err)
+			}
+
+			if usersExportOpts.File == "" {
+				// the users are the output
+				return nil
+			}
+			result := exportedUsers{Path: usersExportOpts.File, Users: len(users)}
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{result.Path},
+				Table: func(w io.Writer) { fmt.Fprintf(w, "Exported %d users to %s\n", result.Users, result.Path) },
+			})
+		},
+	}
+
+	usersExportOpts = &usersExportOptions{}
+)
+
+func init() {
+	usersExportCmd.Flags().StringVarP(&usersExportOpts.File, "file", "f", "", "The file to write the users to, instead of stdout")
+	usersExportCmd.Flags().StringVar(&usersExportOpts.Format, "format", "", "The format to write the users in: csv or jsonl. Inferred from the --file extension, defaults to jsonl")
+	usersExportCmd.Flags().IntVar(&usersExportOpts.PerPage, "per-page", 100, "The number of users to fetch per request")
+
+	usersCmd.AddCommand(usersExportCmd)
+}
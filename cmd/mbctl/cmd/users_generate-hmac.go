@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// userHMAC is the result of generate-hmac.
+type userHMAC struct {
+	Email string `json:"email"`
+	HMAC  string `json:"hmac"`
+}
+
 var (
 	usersGenerateHMACCmd = &cobra.Command{
 		Use:     "generate-hmac",
@@ -14,15 +20,20 @@ var (
 		Example: "mbctl users generate-hmac hana@magicbell.io",
 		Aliases: []string{"gen-hmac"},
 		Args:    cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			email := args[0]
-			hmacEmail := api.GenerateUserEmailHMAC(email)
-
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result := userHMAC{Email: args[0], HMAC: client.Users.GenerateEmailHMAC(args[0])}
 			if usersGenerateHMACCmdSimple {
-				fmt.Println(hmacEmail)
-			} else {
-				logrus.Infof("HMAC of %s is %s", email, hmacEmail)
+				rootOpts.output = outputID
 			}
+
+			return printResult(commandResult{
+				Value: result,
+				IDs:   []string{result.HMAC},
+				Table: func(w io.Writer) {
+					fmt.Fprintln(w, "EMAIL\tHMAC")
+					fmt.Fprintf(w, "%s\t%s\n", result.Email, result.HMAC)
+				},
+			})
 		},
 	}
 	usersGenerateHMACCmdSimple bool
@@ -30,6 +41,7 @@ var (
 
 func init() {
 	usersGenerateHMACCmd.Flags().BoolVarP(&usersGenerateHMACCmdSimple, "simple", "s", false, "Simple output, only the base64 hmac signature")
+	_ = usersGenerateHMACCmd.Flags().MarkDeprecated("simple", "use --output id instead")
 
 	usersCmd.AddCommand(usersGenerateHMACCmd)
 }
@@ -0,0 +1,30 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var usersGetCmd = &cobra.Command{
+	Use:   "get <user>",
+	Short: "Print a user in MagicBell",
+	Long: `Print a user in MagicBell.
+
+The user is ` + userIDHelp + `.`,
+	Example: "mbctl users get external_id:56780",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userID, err := resolveUserID(args[0])
+		if err != nil {
+			return err
+		}
+
+		user, err := client.Users.Get(cmd.Context(), userID)
+		if err != nil {
+			return err
+		}
+
+		return printResult(userResult(user))
+	},
+}
+
+func init() {
+	usersCmd.AddCommand(usersGetCmd)
+}
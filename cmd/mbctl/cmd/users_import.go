@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+type usersImportOptions struct {
+	batchOptions
+	DryRun bool
+}
+
+// userRequest returns the user of the row.
+func (o *usersImportOptions) userRequest(row batchRow) (magicbell.CreateUserRequest, error) {
+	customAttributes, err := row.customAttributes()
+	return magicbell.CreateUserRequest{
+		ExternalID:       stringValue(row.Values["external_id"]),
+		Email:            stringValue(row.Values["email"]),
+		FirstName:        stringValue(row.Values["first_name"]),
+		LastName:         stringValue(row.Values["last_name"]),
+		CustomAttributes: customAttributes,
+	}, err
+}
+
+// upsert updates the user of the row when it exists in MagicBell, identified by its external id or email,
+// and creates it otherwise. With --dry-run, the user is only looked up.
+func (o *usersImportOptions) upsert(ctx context.Context, row batchRow) batchRowResult {
+	req, err := o.userRequest(row)
+	result := batchRowResult{Row: row.Number, Email: req.Email, ExternalID: req.ExternalID}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// validate before looking the user up, unless --skip-validation is set, like the client does before sending
+	if !clientConfig.SkipValidation {
+		if err := req.Validate(); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+	}
+
+	userID := magicbell.EmailUserID(req.Email)
+	if req.ExternalID != "" {
+		userID = magicbell.ExternalIDUserID(req.ExternalID)
+	}
+
+	existing, err := client.Users.Get(ctx, userID)
+	if err != nil && !magicbell.IsNotFoundError(err) {
+		result.Error = err.Error()
+		return result
+	}
+
+	if o.DryRun {
+		result.Action = "would create"
+		if existing != nil {
+			result.ID = existing.ID
+			result.Action = "would update"
+		}
+		return result
+	}
+
+	var user *magicbell.User
+	if existing != nil {
+		result.Action = "updated"
+		user, err = client.Users.Update(ctx, userID, magicbell.UpdateUserRequest(req))
+	} else {
+		result.Action = "created"
+		user, err = client.Users.Create(ctx, req)
+	}
+	if err != nil {
+		result.Action = ""
+		result.Error = err.Error()
+		return result
+	}
+
+	result.ID = user.ID
+	return result
+}
+
+var (
+	usersImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Create or update the users of a CSV or JSONL file",
+		Long: `Create or update the users of a CSV or JSONL file.
+
+The external_id, email, first_name and last_name columns are the fields of each user, use --column to rename
+the columns of your file to these fields. Columns named custom_attributes.<name> are the user's custom attributes,
+and the cells of columns named custom_attributes.<name>: are JSON values, such as 42, true or {"id": 1}.
+The files written by 'mbctl users export' can be imported as-is.
+
+Users which already exist in MagicBell, identified by their external id or else their email, are updated with
+all the data of their row. The other users are created.
+
+With --results, the result of every row is written to a JSONL file. Run the command again with --resume
+to only import the rows which failed or were not imported yet.`,
+		Example: `mbctl users import --file users.csv --column "E-mail=email" --concurrency 8 --results import.jsonl
+mbctl users import --file users.jsonl --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rows, err := usersImportOpts.readRows()
+			if err != nil {
+				return err
+			}
+
+			results, succeeded, err := usersImportOpts.openResults()
+			if err != nil {
+				return err
+			}
+
+			pending := rows[:0:0]
+			for _, row := range rows {
+				if !succeeded[row.Number] {
+					pending = append(pending, row)
+				}
+			}
+
+			usersImportOpts.runBatch(cmd.Context(), pending, func(ctx context.Context, row batchRow) error {
+				return results.add(usersImportOpts.upsert(ctx, row))
+			})
+			if err := results.close(); err != nil {
+				return err
+			}
+
+			summary := results.summary(len(rows) - len(pending))
+			if err := printResult(summary); err != nil {
+				return err
+			}
+			return summary.Value.(*batchSummary).err()
+		},
+	}
+
+	usersImportOpts = &usersImportOptions{}
+)
+
+func init() {
+	usersImportOpts.batchOptions.addFlags(usersImportCmd)
+	usersImportCmd.Flags().BoolVar(&usersImportOpts.DryRun, "dry-run", false, "Validate the rows and look up the users without creating or updating them")
+
+	usersCmd.AddCommand(usersImportCmd)
+}
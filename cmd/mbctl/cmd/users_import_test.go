@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+func TestUsersExportImport_CSVRoundTrip(t *testing.T) {
+	// the custom attributes as decoded from the API
+	var users []magicbell.User
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id": "1", "email": "hana@magicbell.io", "first_name": "Hana", "custom_attributes": {
+			"plan": "pro", "seats": 12, "trial": false, "order": {"id": 1}, "tags": ["a"], "note": "{not json", "nickname": ""
+		}},
+		{"id": "2", "external_id": "56780", "custom_attributes": {"plan": "free", "seats": "many"}}
+	]`), &users))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeUsersCSV(&buf, users))
+	header := strings.SplitN(buf.String(), "\n", 2)[0]
+	assert.Equal(t, "id,external_id,email,first_name,last_name,custom_attributes.nickname:,custom_attributes.note:,"+
+		"custom_attributes.order:,custom_attributes.plan,custom_attributes.seats:,custom_attributes.tags:,custom_attributes.trial:", header)
+
+	rows, err := readCSVRows(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, len(users))
+
+	opts := &usersImportOptions{}
+	for i, row := range rows {
+		req, err := opts.userRequest(row)
+		require.NoError(t, err)
+
+		assert.Equal(t, users[i].Email, req.Email)
+		assert.Equal(t, users[i].ExternalID, req.ExternalID)
+		assert.Equal(t, users[i].FirstName, req.FirstName)
+
+		expected, err := json.Marshal(users[i].CustomAttributes)
+		require.NoError(t, err)
+		actual, err := json.Marshal(req.CustomAttributes)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual))
+	}
+}
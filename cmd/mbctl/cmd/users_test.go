@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveUserID(t *testing.T) {
+	tests := []struct {
+		user     string
+		expected string
+		err      string
+	}{
+		{user: "hana@magicbell.io", expected: "email:hana@magicbell.io"},
+		{user: "56780", expected: "external_id:56780"},
+		{user: "email:hana@magicbell.io", expected: "email:hana@magicbell.io"},
+		{user: "external_id:hana@magicbell.io", expected: "external_id:hana@magicbell.io"},
+		{user: "id:ffffff66-ea4f-4da2-afc6-84148b51657a", expected: "ffffff66-ea4f-4da2-afc6-84148b51657a"},
+		{user: "", err: "missing user"},
+		{user: "email:", err: "missing email in user email:"},
+		{user: "id:", err: "missing id in user id:"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.user, func(t *testing.T) {
+			userID, err := resolveUserID(test.user)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, userID)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+var (
+	usersUpdateCmd = &cobra.Command{
+		Use:   "update <user>",
+		Short: "Update a user in MagicBell, replacing all of their data",
+		Long: `Update a user in MagicBell, replacing all of their data.
+
+The user is ` + userIDHelp + `.`,
+		Example: "mbctl users update hana@magicbell.io --email hana@magicbell.io --first-name Hana --last-name Mohan",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			customAttributes, err := usersUpdateOpts.customAttributes()
+			if err != nil {
+				return err
+			}
+
+			userID, err := resolveUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			user, err := client.Users.Update(cmd.Context(), userID, magicbell.UpdateUserRequest{
+				ExternalID:       usersUpdateOpts.ExternalID,
+				Email:            usersUpdateOpts.Email,
+				FirstName:        usersUpdateOpts.FirstName,
+				LastName:         usersUpdateOpts.LastName,
+				CustomAttributes: customAttributes,
+			})
+			if err != nil {
+				return err
+			}
+
+			return printResult(userResult(user))
+		},
+	}
+
+	usersUpdateOpts = &userOptions{}
+)
+
+func init() {
+	usersUpdateOpts.addFlags(usersUpdateCmd)
+
+	usersCmd.AddCommand(usersUpdateCmd)
+}
@@ -0,0 +1,167 @@
+package magicbell
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvAPIKey         = "MAGICBELL_API_KEY"
+	EnvAPISecret      = "MAGICBELL_API_SECRET" // #nosec G101
+	EnvBaseURL        = "MAGICBELL_BASE_URL"
+	EnvTimeout        = "MAGICBELL_TIMEOUT"
+	EnvSkipValidation = "MAGICBELL_SKIP_VALIDATION"
+	EnvLogBodies      = "MAGICBELL_LOG_BODIES"
+)
+
+// ConfigFromEnv returns a Config built from the MAGICBELL_API_KEY, MAGICBELL_API_SECRET, MAGICBELL_BASE_URL,
+// MAGICBELL_TIMEOUT (a duration such as 10s), MAGICBELL_SKIP_VALIDATION and MAGICBELL_LOG_BODIES
+// environment variables. Unset variables are left empty, use Config.Validate to check the result.
+// The API key and secret may be secret references, which are returned as-is: call Config.ResolveSecrets
+// once you trust the environment, see ResolveSecret.
+func ConfigFromEnv() (Config, error) {
+	values := map[string]interface{}{}
+	for _, env := range []string{EnvAPIKey, EnvAPISecret, EnvBaseURL, EnvTimeout, EnvSkipValidation, EnvLogBodies} {
+		if value, ok := os.LookupEnv(env); ok {
+			values[env] = value
+		}
+	}
+
+	config, err := configFromMap(values)
+	if err != nil {
+		return Config{}, fmt.Errorf("magicbell-go/config: error reading environment: %w", err)
+	}
+	return config, nil
+}
+
+// LoadConfig reads a Config from a YAML (.yaml, .yml), JSON (.json) or TOML (.toml) file.
+// Keys are matched case insensitively and may use snake case, so APIKey, apikey and api_key are equivalent.
+// The Timeout is a duration such as 10s. The API key and secret may be secret references, which are returned
+// as-is: call Config.ResolveSecrets once you trust the file, see ResolveSecret.
+func LoadConfig(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path) // #nosec G304
+	if err != nil {
+		return Config{}, fmt.Errorf("magicbell-go/config: unable to read config file: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	case ".toml":
+		var tree *toml.Tree
+		if tree, err = toml.LoadBytes(data); err == nil {
+			values = tree.ToMap()
+		}
+	default:
+		return Config{}, fmt.Errorf("magicbell-go/config: unsupported config file extension %q", ext)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("magicbell-go/config: unable to parse config file %s: %w", path, err)
+	}
+
+	config, err := configFromMap(values)
+	if err != nil {
+		return Config{}, fmt.Errorf("magicbell-go/config: invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate checks that the APIKey and APISecret are set, and that the BaseURL and Timeout are valid
+// if provided, so misconfiguration is caught before the first request. The returned error, if any, is ValidationErrors.
+func (c Config) Validate() error {
+	var errs ValidationErrors
+
+	if strings.TrimSpace(c.APIKey) == "" {
+		errs.add("APIKey", APIErrorCodeAPIKeyNotProvided, "is missing")
+	}
+	if strings.TrimSpace(c.APISecret) == "" {
+		errs.add("APISecret", APIErrorCodeAPISecretNotProvided, "is missing")
+	}
+	if c.BaseURL != "" && !isValidURL(c.BaseURL) {
+		errs.add("BaseURL", APIErrorCodeParamInvalid, "must be an absolute http or https URL")
+	}
+	if c.Timeout != nil && *c.Timeout <= 0 {
+		errs.add("Timeout", APIErrorCodeParamInvalid, "must be greater than zero")
+	}
+
+	return errs.err()
+}
+
+// configFromMap builds a Config from raw values, matching keys case insensitively and ignoring underscores,
+// dashes and a magicbell prefix, so APIKey, api_key and MAGICBELL_API_KEY are equivalent.
+func configFromMap(values map[string]interface{}) (Config, error) {
+	var config Config
+
+	for rawKey, value := range values {
+		if value == nil {
+			continue
+		}
+
+		key := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(rawKey))
+		key = strings.TrimPrefix(key, "magicbell")
+
+		var err error
+		switch key {
+		case "apikey":
+			config.APIKey = fmt.Sprint(value)
+		case "apisecret":
+			config.APISecret = fmt.Sprint(value)
+		case "baseurl":
+			config.BaseURL = fmt.Sprint(value)
+		case "timeout":
+			var timeout time.Duration
+			if timeout, err = parseDuration(value); err == nil {
+				config.Timeout = &timeout
+			}
+		case "skipvalidation":
+			config.SkipValidation, err = parseBool(value)
+		case "logbodies":
+			config.LogBodies, err = parseBool(value)
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", rawKey, err)
+		}
+	}
+
+	return config, nil
+}
+
+// parseDuration parses a duration string such as 10s. Numbers are nanoseconds, like time.Duration.
+func parseDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case string:
+		return time.ParseDuration(strings.TrimSpace(v))
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	default:
+		return 0, fmt.Errorf("expected a duration, got %T", value)
+	}
+}
+
+func parseBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	default:
+		return false, fmt.Errorf("expected a boolean, got %T", value)
+	}
+}
@@ -0,0 +1,99 @@
+package magicbell
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	expected := Config{
+		APIKey:    "my-key",
+		APISecret: "my-secret",
+		BaseURL:   "https://eu.magicbell.io",
+		Timeout:   newDuration(10 * time.Second),
+	}
+
+	for _, path := range []string{
+		"testdata/config/config.yaml",
+		"testdata/config/config.json",
+		"testdata/config/config.toml",
+	} {
+		t.Run(path, func(t *testing.T) {
+			config, err := LoadConfig(path)
+			require.NoError(t, err)
+			assert.Equal(t, expected, config)
+			assert.NoError(t, config.Validate())
+		})
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	_, err := LoadConfig("testdata/config/missing.yaml")
+	assert.Contains(t, err.Error(), "magicbell-go/config: unable to read config file")
+
+	_, err = LoadConfig("testdata/api/500.txt")
+	assert.EqualError(t, err, `magicbell-go/config: unsupported config file extension ".txt"`)
+
+	_, err = LoadConfig("testdata/config/invalid_timeout.yaml")
+	assert.EqualError(t, err, `magicbell-go/config: invalid config file testdata/config/invalid_timeout.yaml: invalid timeout: time: invalid duration "soon"`)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	for env, value := range map[string]string{
+		EnvAPIKey:         "my-key",
+		EnvAPISecret:      "my-secret",
+		EnvBaseURL:        "https://eu.magicbell.io",
+		EnvTimeout:        "2s",
+		EnvSkipValidation: "true",
+	} {
+		setEnv(t, env, value)
+	}
+
+	config, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		APIKey:         "my-key",
+		APISecret:      "my-secret",
+		BaseURL:        "https://eu.magicbell.io",
+		Timeout:        newDuration(2 * time.Second),
+		SkipValidation: true,
+	}, config)
+
+	setEnv(t, EnvLogBodies, "sometimes")
+	_, err = ConfigFromEnv()
+	assert.EqualError(t, err, `magicbell-go/config: error reading environment: invalid MAGICBELL_LOG_BODIES: strconv.ParseBool: parsing "sometimes": invalid syntax`)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, validConfig.Validate())
+
+	err := Config{
+		BaseURL: "api.magicbell.io",
+		Timeout: newDuration(0),
+	}.Validate()
+	require.Error(t, err)
+	assert.Equal(t, ValidationErrors{
+		{Field: "APIKey", Code: APIErrorCodeAPIKeyNotProvided, Message: "is missing"},
+		{Field: "APISecret", Code: APIErrorCodeAPISecretNotProvided, Message: "is missing"},
+		{Field: "BaseURL", Code: APIErrorCodeParamInvalid, Message: "must be an absolute http or https URL"},
+		{Field: "Timeout", Code: APIErrorCodeParamInvalid, Message: "must be greater than zero"},
+	}, err)
+}
+
+// setEnv sets the environment variable for the duration of the test.
+func setEnv(t *testing.T, key, value string) {
+	previous, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
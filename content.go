@@ -0,0 +1,169 @@
+package magicbell
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"golang.org/x/net/html"
+)
+
+// ContentFormat is the format CreateNotificationRequest.Content is written in.
+// MagicBell renders notification content as HTML, so content in any format other than
+// ContentFormatNone is converted and sanitized into the subset of HTML supported by
+// MagicBell's notification inbox before being sent.
+type ContentFormat string
+
+const (
+	// ContentFormatNone sends the content as-is, this is the default.
+	ContentFormatNone ContentFormat = ""
+	// ContentFormatPlain escapes the content as plain text, preserving line breaks.
+	ContentFormatPlain ContentFormat = "plain"
+	// ContentFormatMarkdown renders the content from Markdown, raw HTML in the Markdown is omitted.
+	ContentFormatMarkdown ContentFormat = "markdown"
+	// ContentFormatHTML sanitizes the content as HTML.
+	ContentFormatHTML ContentFormat = "html"
+)
+
+var (
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+		goldmark.WithRendererOptions(goldmarkhtml.WithHardWraps()),
+	)
+
+	// allowedContentTags are the HTML tags supported by MagicBell's notification inbox.
+	allowedContentTags = map[string]bool{
+		"a": true, "b": true, "blockquote": true, "br": true, "code": true, "del": true,
+		"em": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+		"hr": true, "i": true, "li": true, "ol": true, "p": true, "pre": true, "s": true,
+		"strong": true, "u": true, "ul": true,
+	}
+	// droppedContentTags are removed from content along with everything inside of them.
+	droppedContentTags = map[string]bool{
+		"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+		"noscript": true, "template": true, "title": true, "head": true,
+	}
+	voidContentTags   = map[string]bool{"br": true, "hr": true}
+	allowedURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+)
+
+// ParseContentFormat returns the ContentFormat for s, which may be empty, "plain", "markdown" (or "md") or "html".
+func ParseContentFormat(s string) (ContentFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return ContentFormatNone, nil
+	case "plain", "text":
+		return ContentFormatPlain, nil
+	case "markdown", "md":
+		return ContentFormatMarkdown, nil
+	case "html":
+		return ContentFormatHTML, nil
+	default:
+		return "", fmt.Errorf("magicbell-go/content: unknown content format %q", s)
+	}
+}
+
+// FormatContent converts content written in the given ContentFormat into the
+// subset of HTML supported by MagicBell's notification inbox. The format may be any
+// value accepted by ParseContentFormat, such as "md".
+func FormatContent(format ContentFormat, content string) (string, error) {
+	format, err := ParseContentFormat(string(format))
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case ContentFormatNone:
+		return content, nil
+	case ContentFormatPlain:
+		return strings.Replace(html.EscapeString(content), "\n", "<br>", -1), nil
+	case ContentFormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			return "", fmt.Errorf("magicbell-go/content: error rendering markdown: %w", err)
+		}
+		return sanitizeHTML(buf.String())
+	default: // ContentFormatHTML
+		return sanitizeHTML(content)
+	}
+}
+
+// sanitizeHTML removes all tags and attributes that are not supported by MagicBell's notification inbox.
+// The text inside unsupported tags is kept, except for tags such as <script> which are dropped entirely.
+func sanitizeHTML(content string) (string, error) {
+	var out strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	dropDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+
+		switch tokenType {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", fmt.Errorf("magicbell-go/content: error sanitizing html: %w", err)
+			}
+			return strings.TrimSpace(out.String()), nil
+		case html.TextToken:
+			if dropDepth == 0 {
+				out.WriteString(html.EscapeString(string(tokenizer.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if droppedContentTags[token.Data] {
+				if tokenType == html.StartTagToken {
+					dropDepth++
+				}
+				continue
+			}
+			if dropDepth > 0 || !allowedContentTags[token.Data] {
+				continue
+			}
+
+			out.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if value, ok := sanitizeAttribute(token.Data, attr); ok {
+					out.WriteString(fmt.Sprintf(` %s="%s"`, attr.Key, html.EscapeString(value)))
+				}
+			}
+			out.WriteString(">")
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if droppedContentTags[token.Data] {
+				if dropDepth > 0 {
+					dropDepth--
+				}
+				continue
+			}
+			if dropDepth > 0 || !allowedContentTags[token.Data] || voidContentTags[token.Data] {
+				continue
+			}
+
+			out.WriteString("</" + token.Data + ">")
+		}
+	}
+}
+
+func sanitizeAttribute(tag string, attr html.Attribute) (string, bool) {
+	if tag != "a" || attr.Namespace != "" {
+		return "", false
+	}
+
+	switch attr.Key {
+	case "title":
+		return attr.Val, true
+	case "href":
+		u, err := url.Parse(strings.TrimSpace(attr.Val))
+		if err != nil || !allowedURLSchemes[strings.ToLower(u.Scheme)] {
+			return "", false
+		}
+		return u.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,84 @@
+package magicbell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   ContentFormat
+		content  string
+		expected string
+	}{
+		{
+			name:     "none",
+			format:   ContentFormatNone,
+			content:  "<script>alert(1)</script>",
+			expected: "<script>alert(1)</script>",
+		},
+		{
+			name:     "plain",
+			format:   ContentFormatPlain,
+			content:  "1 < 2\nand <b>bold</b>",
+			expected: "1 &lt; 2<br>and &lt;b&gt;bold&lt;/b&gt;",
+		},
+		{
+			name:     "markdown",
+			format:   ContentFormatMarkdown,
+			content:  "# Welcome\n\nSee **the docs** at [MagicBell](https://developer.magicbell.io \"docs\").\n\n- one\n- ~~two~~",
+			expected: "<h1>Welcome</h1>\n<p>See <strong>the docs</strong> at <a href=\"https://developer.magicbell.io\" title=\"docs\">MagicBell</a>.</p>\n<ul>\n<li>one</li>\n<li><del>two</del></li>\n</ul>",
+		},
+		{
+			name:     "markdown alias",
+			format:   "md",
+			content:  "**Hi**",
+			expected: "<p><strong>Hi</strong></p>",
+		},
+		{
+			name:     "markdown omits raw html and dangerous links",
+			format:   ContentFormatMarkdown,
+			content:  "<script>alert(1)</script>\n\n[click](javascript:alert(1))",
+			expected: "<p><a>click</a></p>",
+		},
+		{
+			name:     "html",
+			format:   ContentFormatHTML,
+			content:  `<div class="x"><p onclick="alert(1)">Hi <img src="x.png"><b>Hana</b><br/></p><style>p {}</style><script>alert("<b>")</script><a href="javascript:alert(1)">bad</a> <a href="mailto:hana@magicbell.io">mail</a></div>`,
+			expected: `<p>Hi <b>Hana</b><br></p><a>bad</a> <a href="mailto:hana@magicbell.io">mail</a>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content, err := FormatContent(test.format, test.content)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, content)
+		})
+	}
+}
+
+func TestFormatContent_UnknownFormat(t *testing.T) {
+	_, err := FormatContent("rtf", "content")
+	assert.EqualError(t, err, `magicbell-go/content: unknown content format "rtf"`)
+}
+
+func TestParseContentFormat(t *testing.T) {
+	for input, expected := range map[string]ContentFormat{
+		"":         ContentFormatNone,
+		"plain":    ContentFormatPlain,
+		"md":       ContentFormatMarkdown,
+		"Markdown": ContentFormatMarkdown,
+		"html":     ContentFormatHTML,
+	} {
+		format, err := ParseContentFormat(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+
+	_, err := ParseContentFormat("rtf")
+	assert.Error(t, err)
+}
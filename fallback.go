@@ -0,0 +1,318 @@
+package magicbell
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeliveredByMagicBell is the Delivery.DeliveredBy value of notifications created in MagicBell.
+const DeliveredByMagicBell = "magicbell"
+
+// FallbackNotifier delivers a notification through another channel when MagicBell is unavailable.
+// Implementations must be safe for concurrent use.
+type FallbackNotifier interface {
+	// Name identifies the fallback, it is used as Delivery.DeliveredBy.
+	Name() string
+	// Notify delivers the notification to its recipients.
+	Notify(ctx context.Context, req CreateNotificationRequest) error
+}
+
+// Delivery records how a notification sent through a ResilientNotifier was delivered.
+type Delivery struct {
+	// DeliveredBy is DeliveredByMagicBell or the Name of the FallbackNotifier which delivered the notification.
+	DeliveredBy string
+	// Notification is the notification created in MagicBell, it is nil when a fallback delivered the notification.
+	Notification *BaseNotification
+	// MagicBellErr is the error returned by MagicBell when a fallback delivered the notification.
+	MagicBellErr error
+}
+
+// FallbackError is returned by ResilientNotifier when neither MagicBell nor any fallback delivered the notification.
+type FallbackError struct {
+	// Err is the error returned by MagicBell.
+	Err error
+	// FallbackErrs are the errors returned by each FallbackNotifier, by name.
+	FallbackErrs map[string]error
+	// fallbacks are the names of the fallbacks in the order they were tried.
+	fallbacks []string
+}
+
+// Error returns the MagicBell error followed by the error of each fallback
+func (e FallbackError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "magicbell-go/fallback: delivery failed: %s: %s", DeliveredByMagicBell, e.Err)
+	for _, name := range e.fallbacks {
+		fmt.Fprintf(&b, "; %s: %s", name, e.FallbackErrs[name])
+	}
+	return b.String()
+}
+
+// Unwrap returns the error returned by MagicBell
+func (e FallbackError) Unwrap() error {
+	return e.Err
+}
+
+// ResilientNotifier wraps IAPI.CreateNotificationC to deliver critical notifications through
+// its Fallbacks, in order, when MagicBell is unavailable. Use NewResilientNotifier to instantiate this struct.
+type ResilientNotifier struct {
+	// API is used to create notifications in MagicBell.
+	API IAPI
+	// Fallbacks are tried in order until one delivers the notification.
+	Fallbacks []FallbackNotifier
+	// ShouldFallback reports whether the error returned by MagicBell means the fallbacks should be used.
+	// By default, open circuits, 5xx responses and network errors (including once retries are exhausted)
+	// are delivered through the fallbacks, while errors such as invalid params are returned as-is.
+	// The fallbacks are never used once the context of the request is done.
+	ShouldFallback func(err error) bool
+}
+
+// NewResilientNotifier instantiates a ResilientNotifier which delivers notifications through the fallbacks
+// when the api is unavailable. Enable Config.CircuitBreaker and the Retry middleware on the api to
+// fail over quickly and only after transient errors have been retried.
+func NewResilientNotifier(api IAPI, fallbacks ...FallbackNotifier) *ResilientNotifier {
+	return &ResilientNotifier{
+		API:       api,
+		Fallbacks: fallbacks,
+	}
+}
+
+// CreateNotificationC sends the notification with MagicBell, falling back to the
+// Fallbacks when MagicBell is unavailable. The returned Delivery records which path delivered it.
+func (n *ResilientNotifier) CreateNotificationC(ctx context.Context, req CreateNotificationRequest) (*Delivery, error) {
+	notification, err := n.API.CreateNotificationC(ctx, req)
+	if err == nil {
+		return &Delivery{DeliveredBy: DeliveredByMagicBell, Notification: notification}, nil
+	}
+
+	// the caller gave up, e.g. canceled or timed out, so the fallbacks would fail or deliver too late
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	shouldFallback := n.ShouldFallback
+	if shouldFallback == nil {
+		shouldFallback = defaultShouldFallback
+	}
+	if len(n.Fallbacks) == 0 || !shouldFallback(err) {
+		return nil, err
+	}
+
+	fallbackErr := FallbackError{Err: err, FallbackErrs: map[string]error{}}
+	for _, fallback := range n.Fallbacks {
+		notifyErr := fallback.Notify(ctx, req)
+		if notifyErr == nil {
+			return &Delivery{DeliveredBy: fallback.Name(), MagicBellErr: err}, nil
+		}
+
+		fallbackErr.fallbacks = append(fallbackErr.fallbacks, fallback.Name())
+		fallbackErr.FallbackErrs[fallback.Name()] = notifyErr
+	}
+
+	return nil, fallbackErr
+}
+
+func defaultShouldFallback(err error) bool {
+	return IsCircuitOpenError(err) || isRetryable(err)
+}
+
+type fallbackFunc struct {
+	name string
+	fn   func(ctx context.Context, req CreateNotificationRequest) error
+}
+
+// FallbackFunc returns a FallbackNotifier with the given name which delivers notifications by calling fn.
+func FallbackFunc(name string, fn func(ctx context.Context, req CreateNotificationRequest) error) FallbackNotifier {
+	return fallbackFunc{name: name, fn: fn}
+}
+
+func (f fallbackFunc) Name() string { return f.name }
+
+func (f fallbackFunc) Notify(ctx context.Context, req CreateNotificationRequest) error {
+	return f.fn(ctx, req)
+}
+
+// LogFallback is a FallbackNotifier which writes every notification as a line of JSON to Writer,
+// so they can be replayed once MagicBell is available again.
+type LogFallback struct {
+	// Writer is where the notifications are written. If not provided, it will default to os.Stderr.
+	Writer io.Writer
+
+	mu sync.Mutex
+}
+
+// Name returns "log"
+func (f *LogFallback) Name() string { return "log" }
+
+// Notify writes the notification as a line of JSON, along with the time it was written at.
+func (f *LogFallback) Notify(ctx context.Context, req CreateNotificationRequest) error {
+	line, err := json.Marshal(struct {
+		Time         time.Time                 `json:"time"`
+		Notification CreateNotificationRequest `json:"notification"`
+	}{time.Now().UTC(), req})
+	if err != nil {
+		return fmt.Errorf("magicbell-go/fallback: error serializing notification: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := f.Writer
+	if w == nil {
+		w = os.Stderr
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("magicbell-go/fallback: error writing notification: %w", err)
+	}
+	return nil
+}
+
+// SMTPFallback is a FallbackNotifier which emails notifications to the recipients which have an email.
+// The notification title is the subject, and the content and action url are the HTML body.
+// The connection to the SMTP server is closed when the context of Notify is done, and STARTTLS
+// is used when the server supports it.
+type SMTPFallback struct {
+	// Addr is the address of the SMTP server, for example smtp.example.com:587.
+	Addr string
+	// Auth is the optional SMTP authentication mechanism.
+	Auth smtp.Auth
+	// From is the sender email address.
+	From string
+
+	// sendMail defaults to f.send, it is replaced in tests.
+	sendMail func(ctx context.Context, to []string, msg []byte) error
+}
+
+// Name returns "smtp"
+func (f *SMTPFallback) Name() string { return "smtp" }
+
+// Notify emails the notification to every recipient with an email.
+// An error is returned if none of the recipients have an email.
+func (f *SMTPFallback) Notify(ctx context.Context, req CreateNotificationRequest) error {
+	var to []string
+	for _, recipient := range req.Recipients {
+		if recipient.Email != "" {
+			to = append(to, recipient.Email)
+		}
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("magicbell-go/fallback: no recipients with an email")
+	}
+
+	content, err := FormatContent(req.ContentFormat, req.Content)
+	if err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", stripNewlines(f.From))
+	fmt.Fprintf(&msg, "To: %s\r\n", stripNewlines(strings.Join(to, ", ")))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", stripNewlines(req.Title)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+	msg.WriteString(content)
+	if req.ActionURL != "" {
+		fmt.Fprintf(&msg, "\r\n<p><a href=\"%s\">%s</a></p>", html.EscapeString(req.ActionURL), html.EscapeString(req.ActionURL))
+	}
+
+	sendMail := f.sendMail
+	if sendMail == nil {
+		sendMail = f.send
+	}
+	if err := sendMail(ctx, to, msg.Bytes()); err != nil {
+		return fmt.Errorf("magicbell-go/fallback: error sending email: %w", err)
+	}
+	return nil
+}
+
+// send is smtp.SendMail, with a connection which is closed when ctx is done.
+func (f *SMTPFallback) send(ctx context.Context, to []string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", f.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// unblock the reads and writes of the SMTP conversation once ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	host, _, err := net.SplitHostPort(f.Addr)
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return withContextErr(ctx, err)
+	}
+	defer c.Close()
+
+	if err := f.converse(c, host, to, msg); err != nil {
+		return withContextErr(ctx, err)
+	}
+	return nil
+}
+
+// converse sends the email over c, like smtp.SendMail does.
+func (f *SMTPFallback) converse(c *smtp.Client, host string, to []string, msg []byte) error {
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if f.Auth != nil {
+		if err := c.Auth(f.Auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(f.From); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// withContextErr returns the error of ctx if it is done, as err is then the result of closing the connection.
+func withContextErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func stripNewlines(s string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(s)
+}
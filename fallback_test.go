@@ -0,0 +1,247 @@
+package magicbell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResilientNotifier(t *testing.T) {
+	failingFallback := FallbackFunc("pager", func(ctx context.Context, req CreateNotificationRequest) error {
+		return errors.New("pager is down")
+	})
+
+	tests := []struct {
+		name          string
+		httpStatus    int
+		fallbacks     func(*bytes.Buffer) []FallbackNotifier
+		checkErr      func(*testing.T, error)
+		checkDelivery func(*testing.T, *Delivery, *bytes.Buffer)
+	}{
+		{
+			name:       "delivered by magicbell",
+			httpStatus: http.StatusCreated,
+			fallbacks: func(buf *bytes.Buffer) []FallbackNotifier {
+				return []FallbackNotifier{&LogFallback{Writer: buf}}
+			},
+			checkErr: assertNoError,
+			checkDelivery: func(t *testing.T, delivery *Delivery, buf *bytes.Buffer) {
+				require.NotNil(t, delivery)
+				assert.Equal(t, DeliveredByMagicBell, delivery.DeliveredBy)
+				assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", delivery.Notification.ID)
+				assert.NoError(t, delivery.MagicBellErr)
+				assert.Empty(t, buf.String())
+			},
+		},
+		{
+			name:       "delivered by second fallback",
+			httpStatus: http.StatusInternalServerError,
+			fallbacks: func(buf *bytes.Buffer) []FallbackNotifier {
+				return []FallbackNotifier{failingFallback, &LogFallback{Writer: buf}}
+			},
+			checkErr: assertNoError,
+			checkDelivery: func(t *testing.T, delivery *Delivery, buf *bytes.Buffer) {
+				require.NotNil(t, delivery)
+				assert.Equal(t, "log", delivery.DeliveredBy)
+				assert.Nil(t, delivery.Notification)
+				assertInternalServerError(t, delivery.MagicBellErr)
+
+				var logged struct {
+					Notification CreateNotificationRequest `json:"notification"`
+				}
+				require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+				assert.Equal(t, initialCreateNotificationRequest.Title, logged.Notification.Title)
+				assert.Equal(t, initialCreateNotificationRequest.Recipients, logged.Notification.Recipients)
+			},
+		},
+		{
+			name:       "api errors are not delivered by fallbacks",
+			httpStatus: http.StatusForbidden,
+			fallbacks: func(buf *bytes.Buffer) []FallbackNotifier {
+				return []FallbackNotifier{&LogFallback{Writer: buf}}
+			},
+			checkErr: assertAPIError(APIErrorCodeForbidden, "not allowed"),
+			checkDelivery: func(t *testing.T, delivery *Delivery, buf *bytes.Buffer) {
+				assert.Nil(t, delivery)
+				assert.Empty(t, buf.String())
+			},
+		},
+		{
+			name:       "all fallbacks failed",
+			httpStatus: http.StatusInternalServerError,
+			fallbacks: func(buf *bytes.Buffer) []FallbackNotifier {
+				return []FallbackNotifier{failingFallback}
+			},
+			checkErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.EqualError(t, err, "magicbell-go/fallback: delivery failed: magicbell: HTTP 500 Internal Server Error; pager: pager is down")
+				assert.True(t, IsInternalServerError(errors.Unwrap(err)))
+			},
+			checkDelivery: func(t *testing.T, delivery *Delivery, buf *bytes.Buffer) {
+				assert.Nil(t, delivery)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, "/notifications", http.MethodPost, test.httpStatus, func(config Config) {
+				var buf bytes.Buffer
+				notifier := NewResilientNotifier(New(config), test.fallbacks(&buf)...)
+
+				delivery, err := notifier.CreateNotificationC(context.Background(), initialCreateNotificationRequest)
+				test.checkErr(t, err)
+				test.checkDelivery(t, delivery, &buf)
+			})
+		})
+	}
+}
+
+func TestResilientNotifier_CircuitOpen(t *testing.T) {
+	config := validConfig.withBaseURL("http://127.0.0.1:0")
+	config.CircuitBreaker = &CircuitBreakerSettings{FailureThreshold: 1}
+
+	var delivered []string
+	notifier := NewResilientNotifier(New(config), FallbackFunc("custom", func(ctx context.Context, req CreateNotificationRequest) error {
+		delivered = append(delivered, req.Title)
+		return nil
+	}))
+
+	for i := 0; i < 2; i++ {
+		delivery, err := notifier.CreateNotificationC(context.Background(), initialCreateNotificationRequest)
+		require.NoError(t, err)
+		assert.Equal(t, "custom", delivery.DeliveredBy)
+	}
+
+	delivery, err := notifier.CreateNotificationC(context.Background(), initialCreateNotificationRequest)
+	require.NoError(t, err)
+	assert.True(t, IsCircuitOpenError(delivery.MagicBellErr))
+	assert.Len(t, delivered, 3)
+}
+
+func TestResilientNotifier_ContextDone(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	var delivered bool
+	notifier := NewResilientNotifier(New(validConfig.withBaseURL(srv.URL)), FallbackFunc("custom", func(ctx context.Context, req CreateNotificationRequest) error {
+		delivered = true
+		return nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	delivery, err := notifier.CreateNotificationC(ctx, initialCreateNotificationRequest)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Nil(t, delivery)
+	assert.False(t, delivered)
+}
+
+func TestSMTPFallback(t *testing.T) {
+	var sentTo []string
+	var sentMsg string
+
+	fallback := &SMTPFallback{
+		Addr: "smtp.example.com:587",
+		From: "alerts@example.com",
+		sendMail: func(ctx context.Context, to []string, msg []byte) error {
+			sentTo = to
+			sentMsg = string(msg)
+			return nil
+		},
+	}
+	assert.Equal(t, "smtp", fallback.Name())
+
+	err := fallback.Notify(context.Background(), CreateNotificationRequest{
+		Title: "New login\r\nBcc: everyone@example.com",
+		Recipients: []NotificationRecipient{
+			{Email: "hana@magicbell.io"},
+			{ExternalID: "1924"},
+		},
+		Content:       "Someone logged in from **Paris**",
+		ContentFormat: ContentFormatMarkdown,
+		ActionURL:     "https://example.com/security?a=1&b=2",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"hana@magicbell.io"}, sentTo)
+	assert.Equal(t, "From: alerts@example.com\r\n"+
+		"To: hana@magicbell.io\r\n"+
+		"Subject: New login Bcc: everyone@example.com\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/html; charset=utf-8\r\n\r\n"+
+		"<p>Someone logged in from <strong>Paris</strong></p>\r\n"+
+		`<p><a href="https://example.com/security?a=1&amp;b=2">https://example.com/security?a=1&amp;b=2</a></p>`, sentMsg)
+
+	err = fallback.Notify(context.Background(), CreateNotificationRequest{
+		Title:      "New login",
+		Recipients: []NotificationRecipient{{ExternalID: "1924"}},
+	})
+	assert.EqualError(t, err, "magicbell-go/fallback: no recipients with an email")
+}
+
+func TestSMTPFallback_ContextDeadline(t *testing.T) {
+	// a server which accepts connections and never greets the client
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	fallback := &SMTPFallback{Addr: listener.Addr().String(), From: "alerts@example.com"}
+	start := time.Now()
+	err = fallback.Notify(ctx, CreateNotificationRequest{
+		Title:      "New login",
+		Recipients: []NotificationRecipient{{Email: "hana@magicbell.io"}},
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func TestLogFallback_DefaultWriter(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fallback := &LogFallback{}
+	require.NoError(t, fallback.Notify(context.Background(), CreateNotificationRequest{Title: "New login"}))
+	w.Close()
+
+	var line struct {
+		Notification struct {
+			Title string `json:"title"`
+		} `json:"notification"`
+	}
+	require.NoError(t, json.NewDecoder(r).Decode(&line))
+	assert.Equal(t, "New login", line.Notification.Title)
+}
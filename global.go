@@ -1,6 +1,11 @@
 package magicbell
 
-var api IAPI
+import "context"
+
+var (
+	api      IAPI
+	projects = NewRegistry()
+)
 
 // Init initializes the global MagicBell API. This allows for shorthand
 // access to all the API methods instead of instantiating and managing your own
@@ -8,3 +13,34 @@ var api IAPI
 func Init(config Config) {
 	api = New(config)
 }
+
+// InitProject initializes a named MagicBell project for use with the global shortcuts.
+// Global shortcuts accepting a context.Context use the project named with WithProject,
+// and Project returns the project's IAPI for the other shortcuts.
+func InitProject(name string, config Config) {
+	projects.Register(name, New(config))
+}
+
+// Project returns the IAPI of the project initialized with InitProject.
+// Like calling the global shortcuts before Init, this panics if the project was not initialized.
+func Project(name string) IAPI {
+	projectAPI, err := projects.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return projectAPI
+}
+
+// Projects returns the Registry of projects initialized with InitProject.
+func Projects() *Registry {
+	return projects
+}
+
+// apiFor returns the IAPI of the project named on ctx with WithProject,
+// or the global API initialized with Init.
+func apiFor(ctx context.Context) (IAPI, error) {
+	if name, ok := ProjectFromContext(ctx); ok {
+		return projects.Get(name)
+	}
+	return api, nil
+}
@@ -13,7 +13,10 @@ import (
 )
 
 func runGlobalTest(config Config, fn func()) {
-	defer func() { api = nil }()
+	defer func() {
+		api = nil
+		projects = NewRegistry()
+	}()
 	Init(config)
 	fn()
 }
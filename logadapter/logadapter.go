@@ -0,0 +1,46 @@
+// Package logadapter adapts popular logging libraries to the magicbell.Logger interface.
+//
+//	magicbell.Init(magicbell.Config{
+//		APIKey:    "my-key",
+//		APISecret: "my-secret",
+//		Logger:    logadapter.Logrus(logrus.StandardLogger()),
+//	})
+package logadapter
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+type logrusLogger struct {
+	logger logrus.FieldLogger
+}
+
+// Logrus returns a magicbell.Logger which logs to the logrus logger with the key-value pairs as fields.
+func Logrus(logger logrus.FieldLogger) magicbell.Logger {
+	return logrusLogger{logger: logger}
+}
+
+func (l logrusLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.WithFields(toFields(keysAndValues)).Debug(msg)
+}
+
+// toFields converts alternating key-value pairs into logrus.Fields.
+// A key without a value is logged with a nil value.
+func toFields(keysAndValues []interface{}) logrus.Fields {
+	fields := make(logrus.Fields, len(keysAndValues)/2)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		if i+1 < len(keysAndValues) {
+			fields[key] = keysAndValues[i+1]
+		} else {
+			fields[key] = nil
+		}
+	}
+
+	return fields
+}
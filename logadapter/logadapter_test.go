@@ -0,0 +1,23 @@
+package logadapter
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogrus(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	Logrus(logger).Debug("sending request", "method", "POST", "status", 201, "dangling")
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.DebugLevel, entry.Level)
+	assert.Equal(t, "sending request", entry.Message)
+	assert.Equal(t, logrus.Fields{"method": "POST", "status": 201, "dangling": nil}, entry.Data)
+}
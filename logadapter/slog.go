@@ -0,0 +1,24 @@
+//go:build go1.21
+// +build go1.21
+
+package logadapter
+
+import (
+	"log/slog"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// Slog returns a magicbell.Logger which logs to the slog logger with the key-value pairs as attributes.
+// This adapter is only available when building with Go 1.21 or later.
+func Slog(logger *slog.Logger) magicbell.Logger {
+	return slogLogger{logger: logger}
+}
+
+func (l slogLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, keysAndValues...)
+}
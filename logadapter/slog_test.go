@@ -0,0 +1,29 @@
+//go:build go1.21
+// +build go1.21
+
+package logadapter
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	Slog(logger).Debug("sending request", "method", "POST", "status", 201)
+
+	assert.Equal(t, "level=DEBUG msg=\"sending request\" method=POST status=201\n", buf.String())
+}
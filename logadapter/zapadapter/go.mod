@@ -0,0 +1,11 @@
+module github.com/tizz98/magicbell-go/logadapter/zapadapter
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	github.com/tizz98/magicbell-go v0.0.0
+	go.uber.org/zap v1.16.0
+)
+
+replace github.com/tizz98/magicbell-go => ../../
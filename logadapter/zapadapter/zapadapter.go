@@ -0,0 +1,28 @@
+// Package zapadapter adapts a zap logger to the magicbell.Logger interface.
+// It is a separate module so zap is only downloaded by projects using it.
+//
+//	magicbell.Init(magicbell.Config{
+//		APIKey:    "my-key",
+//		APISecret: "my-secret",
+//		Logger:    zapadapter.New(zapLogger),
+//	})
+package zapadapter
+
+import (
+	"go.uber.org/zap"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+type zapLogger struct {
+	logger *zap.SugaredLogger
+}
+
+// New returns a magicbell.Logger which logs to the zap logger with the key-value pairs as fields.
+func New(logger *zap.Logger) magicbell.Logger {
+	return zapLogger{logger: logger.Sugar()}
+}
+
+func (l zapLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debugw(msg, keysAndValues...)
+}
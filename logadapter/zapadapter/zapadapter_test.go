@@ -0,0 +1,23 @@
+package zapadapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	New(zap.New(core)).Debug("sending request", "method", "POST", "status", 201)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "sending request", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"method": "POST", "status": int64(201)}, entries[0].ContextMap())
+}
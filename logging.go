@@ -0,0 +1,88 @@
+package magicbell
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	userHMACHeader = "X-MAGICBELL-USER-HMAC" // #nosec G101
+
+	redactedValue = "[REDACTED]"
+)
+
+// redactedHeaders are never logged with their real value.
+var redactedHeaders = []string{apiSecretHeader, userHMACHeader, "Authorization"}
+
+// Logger is a structured logger used to debug the HTTP requests made to and responses
+// received from the MagicBell API. Implementations must be safe for concurrent use.
+// See the logadapter sub-package for adapters to log/slog and logrus, and logadapter/zapadapter for zap.
+type Logger interface {
+	// Debug logs msg at debug level with alternating key-value pairs, for example
+	// Debug("magicbell-go: sending request", "method", "POST", "url", "https://api.magicbell.io/users").
+	Debug(msg string, keysAndValues ...interface{})
+}
+
+// logRequest logs the request with secret headers redacted, and its body when Config.LogBodies is set.
+func (a *API) logRequest(r *http.Request) {
+	keysAndValues := []interface{}{
+		"method", r.Method,
+		"url", r.URL.String(),
+		"headers", redactHeaders(r.Header),
+	}
+
+	if a.config.LogBodies && r.GetBody != nil {
+		if body, err := r.GetBody(); err == nil {
+			if data, err := ioutil.ReadAll(body); err == nil {
+				keysAndValues = append(keysAndValues, "body", string(data))
+			}
+		}
+	}
+
+	a.config.Logger.Debug("magicbell-go: sending request", keysAndValues...)
+}
+
+// logResponse logs the response with secret headers redacted, and its body when Config.LogBodies is set.
+// The response body is buffered so that it can still be read by the caller.
+func (a *API) logResponse(r *http.Request, resp *http.Response, duration time.Duration, err error) {
+	keysAndValues := []interface{}{
+		"method", r.Method,
+		"url", r.URL.String(),
+		"duration", duration,
+	}
+
+	if err != nil {
+		a.config.Logger.Debug("magicbell-go: request failed", append(keysAndValues, "error", err.Error())...)
+		return
+	}
+
+	keysAndValues = append(keysAndValues,
+		"status", resp.StatusCode,
+		"headers", redactHeaders(resp.Header),
+	)
+
+	if a.config.LogBodies && resp.Body != nil {
+		data, readErr := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+		if readErr == nil {
+			keysAndValues = append(keysAndValues, "body", string(data))
+		}
+	}
+
+	a.config.Logger.Debug("magicbell-go: received response", keysAndValues...)
+}
+
+// redactHeaders returns a copy of the headers with secret values replaced.
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, key := range redactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, redactedValue)
+		}
+	}
+	return redacted
+}
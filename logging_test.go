@@ -0,0 +1,97 @@
+package magicbell
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+type fakeLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *fakeLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fields := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	l.entries = append(l.entries, logEntry{msg: msg, fields: fields})
+}
+
+func TestAPI_Logger(t *testing.T) {
+	for _, logBodies := range []bool{false, true} {
+		t.Run(fmt.Sprintf("log bodies %t", logBodies), func(t *testing.T) {
+			runServer(t, "/notifications", http.MethodPost, http.StatusCreated, func(config Config) {
+				logger := &fakeLogger{}
+				config.Logger = logger
+				config.LogBodies = logBodies
+
+				notification, err := New(config).CreateNotification(initialCreateNotificationRequest)
+				require.NoError(t, err)
+				require.NotNil(t, notification)
+				assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", notification.ID)
+
+				require.Len(t, logger.entries, 2)
+
+				request := logger.entries[0]
+				assert.Equal(t, "magicbell-go: sending request", request.msg)
+				assert.Equal(t, http.MethodPost, request.fields["method"])
+				headers := request.fields["headers"].(http.Header)
+				assert.Equal(t, "key", headers.Get(apiKeyHeader))
+				assert.Equal(t, redactedValue, headers.Get(apiSecretHeader))
+
+				response := logger.entries[1]
+				assert.Equal(t, "magicbell-go: received response", response.msg)
+				assert.Equal(t, http.StatusCreated, response.fields["status"])
+
+				if logBodies {
+					assert.Contains(t, request.fields["body"], `"title":"Ticket assigned to you: Do you offer demos?"`)
+					assert.Contains(t, response.fields["body"], `"id": "ffffff66-ea4f-4da2-afc6-84148b51657a"`)
+				} else {
+					assert.NotContains(t, request.fields, "body")
+					assert.NotContains(t, response.fields, "body")
+				}
+			})
+		})
+	}
+}
+
+func TestAPI_LoggerRequestFailed(t *testing.T) {
+	logger := &fakeLogger{}
+	config := validConfig.withBaseURL("http://127.0.0.1:0")
+	config.Logger = logger
+
+	_, err := New(config).CreateUser(initialCreateUserRequest)
+	require.Error(t, err)
+
+	require.Len(t, logger.entries, 2)
+	assert.Equal(t, "magicbell-go: request failed", logger.entries[1].msg)
+	assert.NotEmpty(t, logger.entries[1].fields["error"])
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set(apiKeyHeader, "key")
+	headers.Set(apiSecretHeader, "secret")
+	headers.Set(userHMACHeader, "hmac")
+
+	redacted := redactHeaders(headers)
+	assert.Equal(t, "key", redacted.Get(apiKeyHeader))
+	assert.Equal(t, redactedValue, redacted.Get(apiSecretHeader))
+	assert.Equal(t, redactedValue, redacted.Get(userHMACHeader))
+	assert.Empty(t, redacted.Get("Authorization"))
+	assert.Equal(t, "secret", headers.Get(apiSecretHeader), "original headers must not be modified")
+}
@@ -0,0 +1,44 @@
+package magicbell
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics receives measurements for every API request, it can be used to build dashboards of
+// request rates, error rates and latency without wrapping every IAPI method. Implementations
+// must be safe for concurrent use. See the prometheus sub-package for a ready-made implementation.
+type Metrics interface {
+	// RequestStarted is called right before an API request is sent.
+	// The operation is the name of the IAPI method making the request, for example CreateNotification.
+	RequestStarted(operation string)
+	// RequestFinished is called once an API request started with RequestStarted has completed.
+	// The statusCode is 0 when no HTTP response was received, and errorCode is the first
+	// APIErrorCode returned by the API, or an empty string if there is none.
+	RequestFinished(operation string, statusCode int, errorCode APIErrorCode, duration time.Duration)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RequestStarted(string)                                    {}
+func (noopMetrics) RequestFinished(string, int, APIErrorCode, time.Duration) {}
+
+// measureOperation is a Middleware which reports the Operation to Config.Metrics.
+func (a *API) measureOperation(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) error {
+		start := time.Now()
+		a.config.Metrics.RequestStarted(op.Name)
+
+		err := next(ctx, op)
+		a.config.Metrics.RequestFinished(op.Name, op.StatusCode, firstErrorCode(responseError(op.Response, err)), time.Since(start))
+		return err
+	}
+}
+
+// firstErrorCode returns the first APIErrorCode contained in err, if any.
+func firstErrorCode(err error) APIErrorCode {
+	if codes := errorCodes(err); len(codes) > 0 {
+		return APIErrorCode(codes[0])
+	}
+	return ""
+}
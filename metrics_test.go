@@ -0,0 +1,106 @@
+package magicbell
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	operation  string
+	statusCode int
+	errorCode  APIErrorCode
+}
+
+type fakeMetrics struct {
+	mu       sync.Mutex
+	inFlight int
+	finished []recordedRequest
+}
+
+func (m *fakeMetrics) RequestStarted(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight++
+}
+
+func (m *fakeMetrics) RequestFinished(operation string, statusCode int, errorCode APIErrorCode, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight--
+	m.finished = append(m.finished, recordedRequest{operation, statusCode, errorCode})
+}
+
+func TestAPI_Metrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		method     string
+		httpStatus int
+		call       func(IAPI) error
+		expected   recordedRequest
+	}{
+		{
+			name:       "success",
+			path:       "/notifications",
+			method:     http.MethodPost,
+			httpStatus: http.StatusCreated,
+			call: func(api IAPI) error {
+				_, err := api.CreateNotification(initialCreateNotificationRequest)
+				return err
+			},
+			expected: recordedRequest{"CreateNotification", 201, ""},
+		},
+		{
+			name:       "api error",
+			path:       "/users",
+			method:     http.MethodPost,
+			httpStatus: http.StatusBadRequest,
+			call: func(api IAPI) error {
+				_, err := api.CreateUser(initialCreateUserRequest)
+				return err
+			},
+			expected: recordedRequest{"CreateUser", 400, APIErrorCodeUserEmailNotProvided},
+		},
+		{
+			name:       "internal server error",
+			path:       "/users/7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c",
+			method:     http.MethodPut,
+			httpStatus: http.StatusInternalServerError,
+			call: func(api IAPI) error {
+				_, err := api.UpdateUser("7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c", initialUpdateUserRequest)
+				return err
+			},
+			expected: recordedRequest{"UpdateUser", 500, ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, test.path, test.method, test.httpStatus, func(config Config) {
+				metrics := &fakeMetrics{}
+				config.Metrics = metrics
+
+				_ = test.call(New(config))
+
+				assert.Equal(t, 0, metrics.inFlight)
+				require.Len(t, metrics.finished, 1)
+				assert.Equal(t, test.expected, metrics.finished[0])
+			})
+		})
+	}
+}
+
+func TestAPI_MetricsNoResponse(t *testing.T) {
+	metrics := &fakeMetrics{}
+	config := validConfig.withBaseURL("http://127.0.0.1:0")
+	config.Metrics = metrics
+
+	_, err := New(config).CreateUser(initialCreateUserRequest)
+	require.Error(t, err)
+	assert.Equal(t, []recordedRequest{{"CreateUser", 0, ""}}, metrics.finished)
+}
@@ -0,0 +1,111 @@
+package magicbell
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Operation is a single MagicBell API call as it passes through the Middleware chain.
+// Middleware may modify the Operation before calling the next Handler, for example to add Header values.
+type Operation struct {
+	// Name is the name of the IAPI method making the request, for example CreateNotification.
+	Name string
+	// Method is the HTTP method of the request.
+	Method string
+	// Endpoint is the path of the request relative to Config.BaseURL, for example users/123.
+	Endpoint string
+	// Header contains additional HTTP headers to send with the request.
+	Header http.Header
+	// Request is the value serialized as the JSON request body, it is nil when there is no body.
+	Request interface{}
+	// Response is a pointer the JSON response body is decoded into. Middleware which do not
+	// call the next Handler may fill it using json.Unmarshal to provide their own response.
+	Response interface{}
+	// StatusCode is the HTTP status code of the response, it is 0 until a response has been received.
+	StatusCode int
+}
+
+// Handler executes an Operation, returning an error if the HTTP request failed.
+// Errors returned by the API in the response body are decoded into Operation.Response instead.
+type Handler func(ctx context.Context, op *Operation) error
+
+// Middleware wraps a Handler to add behavior around every API request, such as adding headers,
+// audit logging or short-circuiting requests by not calling next. See Config.Middleware.
+type Middleware func(next Handler) Handler
+
+// chain wraps the handler with the middleware, the first middleware being the outermost.
+func chain(handler Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// RetryPolicy configures the Retry middleware.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times an Operation is attempted, including the first attempt.
+	// If not provided, it will default to 3.
+	MaxAttempts int
+	// Backoff is the time to wait before the first retry, it doubles after every attempt.
+	// If not provided, it will default to 100 milliseconds.
+	Backoff time.Duration
+	// RetryIf reports whether the Operation should be retried after the error.
+	// If not provided, 5xx responses and network errors are retried.
+	RetryIf func(op *Operation, err error) bool
+}
+
+// Retry returns a Middleware which retries failed Operations according to the RetryPolicy.
+// The last error is returned once all attempts are exhausted. Note that retrying CreateNotification
+// after a 5xx response may result in the notification being sent more than once.
+func Retry(policy RetryPolicy) Middleware {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 100 * time.Millisecond
+	}
+	if policy.RetryIf == nil {
+		policy.RetryIf = func(op *Operation, err error) bool { return isRetryable(err) }
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			backoff := policy.Backoff
+
+			for attempt := 1; ; attempt++ {
+				err := next(ctx, op)
+				if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.RetryIf(op, err) {
+					return err
+				}
+
+				timer := time.NewTimer(backoff)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+				backoff *= 2
+			}
+		}
+	}
+}
+
+// isRetryable returns true for errors which may succeed when retried: 5xx responses and network errors.
+// Requests canceled or timed out by their context are not retried, while requests which exceeded
+// Config.Timeout are.
+func isRetryable(err error) bool {
+	if IsInternalServerError(err) {
+		return true
+	}
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	// the error of a Config.Timeout also matches context.DeadlineExceeded, but is not the context's error
+	return !errors.Is(urlErr.Err, context.Canceled) && urlErr.Err != context.DeadlineExceeded
+}
@@ -0,0 +1,209 @@
+package magicbell
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSequenceServer responds with the given status codes in order, repeating the last one,
+// using the testdata files for POST /notifications.
+func runSequenceServer(t *testing.T, statuses []int, fn func(config Config, requests *int32)) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&requests, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+
+		dataPath := "testdata/api/notifications_post_201.json"
+		if statuses[i] == http.StatusInternalServerError {
+			dataPath = "testdata/api/500.txt"
+		} else if statuses[i] == http.StatusForbidden {
+			dataPath = "testdata/api/notifications_post_403.json"
+		}
+
+		data, err := ioutil.ReadFile(dataPath)
+		require.NoError(t, err)
+
+		w.WriteHeader(statuses[i])
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	fn(validConfig.withBaseURL(srv.URL), &requests)
+}
+
+func TestAPI_Middleware(t *testing.T) {
+	var calls []string
+	var tenant string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Tenant")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"notification":{"id":"1"}}`))
+	}))
+	defer srv.Close()
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				calls = append(calls, name+" before "+op.Name)
+				err := next(ctx, op)
+				calls = append(calls, name+" after "+http.StatusText(op.StatusCode))
+				return err
+			}
+		}
+	}
+
+	config := validConfig.withBaseURL(srv.URL)
+	config.Middleware = []Middleware{
+		record("outer"),
+		func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				op.Header.Set("X-Tenant", "acme")
+				return next(ctx, op)
+			}
+		},
+		record("inner"),
+	}
+
+	notification, err := New(config).CreateNotification(initialCreateNotificationRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "1", notification.ID)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, []string{
+		"outer before CreateNotification",
+		"inner before CreateNotification",
+		"inner after Created",
+		"outer after Created",
+	}, calls)
+}
+
+func TestAPI_MiddlewareShortCircuit(t *testing.T) {
+	config := validConfig.withBaseURL("http://127.0.0.1:0")
+	config.Middleware = []Middleware{
+		func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				if op.Name != "CreateNotification" {
+					return next(ctx, op)
+				}
+
+				req := op.Request.(createNotificationRequest)
+				assert.Equal(t, initialCreateNotificationRequest.Title, req.Notification.Title)
+				return json.Unmarshal([]byte(`{"notification":{"id":"staging"}}`), op.Response)
+			}
+		},
+	}
+
+	notification, err := New(config).CreateNotification(initialCreateNotificationRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", notification.ID)
+}
+
+func TestRetry(t *testing.T) {
+	retry := Retry(RetryPolicy{Backoff: time.Millisecond})
+
+	tests := []struct {
+		name             string
+		statuses         []int
+		expectedRequests int32
+		checkErr         func(*testing.T, error)
+	}{
+		{
+			name:             "succeeds after retries",
+			statuses:         []int{500, 500, 201},
+			expectedRequests: 3,
+			checkErr:         assertNoError,
+		},
+		{
+			name:             "attempts exhausted",
+			statuses:         []int{500},
+			expectedRequests: 3,
+			checkErr:         assertInternalServerError,
+		},
+		{
+			name:             "api errors are not retried",
+			statuses:         []int{403, 201},
+			expectedRequests: 1,
+			checkErr:         assertAPIError(APIErrorCodeForbidden, "not allowed"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runSequenceServer(t, test.statuses, func(config Config, requests *int32) {
+				config.Middleware = []Middleware{retry}
+
+				_, err := New(config).CreateNotification(initialCreateNotificationRequest)
+				test.checkErr(t, err)
+				assert.Equal(t, test.expectedRequests, atomic.LoadInt32(requests))
+			})
+		})
+	}
+}
+
+func TestRetry_ContextCanceled(t *testing.T) {
+	runSequenceServer(t, []int{500}, func(config Config, requests *int32) {
+		config.Middleware = []Middleware{Retry(RetryPolicy{MaxAttempts: 5, Backoff: time.Hour})}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := New(config).CreateNotificationC(ctx, initialCreateNotificationRequest)
+		assertInternalServerError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	get := func(ctx context.Context, timeout time.Duration) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		resp, err := (&http.Client{Timeout: timeout}).Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelExpired()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "internal server error", err: InternalServerError{StatusCode: 503}, want: true},
+		{name: "api errors", err: APIErrors{{Code: APIErrorCodeForbidden}}, want: false},
+		{name: "connection refused", err: &url.Error{Op: "Post", URL: srv.URL, Err: errors.New("connection refused")}, want: true},
+		{name: "client timeout", err: get(context.Background(), 10*time.Millisecond), want: true},
+		{name: "context canceled", err: get(canceled, time.Second), want: false},
+		{name: "context deadline exceeded", err: get(expired, time.Second), want: false},
+		{name: "bare context error", err: context.Canceled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
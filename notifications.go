@@ -3,15 +3,28 @@ package magicbell
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // NotificationRecipient is a possible recipient of a notification.
 // Generally Email should be specified, but ExternalID can also be provided if the email is not available.
+// Any of the optional user attributes that are set will be used to create or update
+// the user in MagicBell when the notification is sent, so there is no need to call CreateUser beforehand.
 type NotificationRecipient struct {
 	// Email is the email of the recipient to send the notification to.
 	Email string `json:"email"`
 	// ExternalID is the unique string to identify the user in your database.
 	ExternalID string `json:"external_id"`
+	// FirstName is the recipient's first name, this is optional.
+	FirstName string `json:"first_name,omitempty"`
+	// LastName is the recipient's last name, this is optional.
+	LastName string `json:"last_name,omitempty"`
+	// PhoneNumbers are the recipient's phone numbers, this is optional.
+	// MagicBell accepts a list of numbers as `phone_numbers` rather than a single `phone` field.
+	PhoneNumbers []string `json:"phone_numbers,omitempty"`
+	// CustomAttributes are any custom attributes to store on the recipient's user, this is optional.
+	CustomAttributes CustomAttributes `json:"custom_attributes,omitempty"`
 }
 
 // CreateNotificationRequest is the data required to create a new notification
@@ -23,6 +36,10 @@ type CreateNotificationRequest struct {
 	Recipients []NotificationRecipient `json:"recipients"`
 	// Content is the content of the notification to send
 	Content string `json:"content,omitempty"`
+	// ContentFormat is the format Content is written in, for example ContentFormatMarkdown.
+	// If set, Content is converted and sanitized into HTML before the notification is sent.
+	// If not provided, Content is sent as-is.
+	ContentFormat ContentFormat `json:"-"`
 	// CustomAttributes are a set of key-value pairs that you can attach to a notification
 	CustomAttributes CustomAttributes `json:"custom_attributes,omitempty"`
 	// ActionURL is a URL to redirect the user to when they click on the notification in MagicBell's embeddable notification center.
@@ -50,7 +67,241 @@ type BaseNotification struct {
 // Notification represents a full notification when retrieved from MagicBell.
 type Notification struct {
 	BaseNotification
-	// TODO: more fields
+	// Title is the title of the notification
+	Title string `json:"title"`
+	// Content is the content of the notification
+	Content string `json:"content"`
+	// ActionURL is the URL the user is redirected to when they click on the notification
+	ActionURL string `json:"action_url"`
+	// Category is the category this notification belongs to
+	Category string `json:"category"`
+	// Topic is the topic this notification belongs to
+	Topic string `json:"topic"`
+	// CustomAttributes are the key-value pairs attached to the notification
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
+	// SentAt is when the notification was sent
+	SentAt *Timestamp `json:"sent_at"`
+	// SeenAt is when the user saw the notification, if they have
+	SeenAt *Timestamp `json:"seen_at"`
+	// ReadAt is when the user read the notification, if they have
+	ReadAt *Timestamp `json:"read_at"`
+	// ArchivedAt is when the user archived the notification, if they have
+	ArchivedAt *Timestamp `json:"archived_at"`
+}
+
+// UserIdentity identifies the user whose notifications are retrieved or changed, by email or external id.
+// If both are set, Email is used.
+type UserIdentity struct {
+	// Email is the user's email
+	Email string
+	// ExternalID is the user's external id
+	ExternalID string
+}
+
+// Validate checks that the user can be identified.
+func (u UserIdentity) Validate() error {
+	var errs ValidationErrors
+	if u.Email == "" && u.ExternalID == "" {
+		errs.add("user", APIErrorCodeParamMissing, "must have an email or external id")
+	}
+	return errs.err()
+}
+
+// callOption sends the headers that scope the request to the user.
+func (u UserIdentity) callOption() CallOption {
+	if u.Email != "" {
+		return WithHeader(userEmailHeader, u.Email)
+	}
+	return WithHeader(userExternalIDHeader, u.ExternalID)
+}
+
+// ListNotificationsRequest filters and paginates the notifications returned by NotificationsService.List.
+// All fields are optional.
+type ListNotificationsRequest struct {
+	// Page is the page of notifications to return, starting at 1
+	Page int
+	// PerPage is the number of notifications per page
+	PerPage int
+	// Read only returns read notifications when true, or unread notifications when false
+	Read *bool
+	// Seen only returns seen notifications when true, or unseen notifications when false
+	Seen *bool
+	// Archived only returns archived notifications when true, or unarchived notifications when false
+	Archived *bool
+	// Category only returns the notifications of this category
+	Category string
+	// Topic only returns the notifications of this topic
+	Topic string
+}
+
+func (r ListNotificationsRequest) query() url.Values {
+	query := url.Values{}
+	if r.Page > 0 {
+		query.Set("page", strconv.Itoa(r.Page))
+	}
+	if r.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(r.PerPage))
+	}
+	if r.Read != nil {
+		query.Set("read", strconv.FormatBool(*r.Read))
+	}
+	if r.Seen != nil {
+		query.Set("seen", strconv.FormatBool(*r.Seen))
+	}
+	if r.Archived != nil {
+		query.Set("archived", strconv.FormatBool(*r.Archived))
+	}
+	if r.Category != "" {
+		query.Set("category", r.Category)
+	}
+	if r.Topic != "" {
+		query.Set("topic", r.Topic)
+	}
+	return query
+}
+
+// NotificationList is a page of a user's notifications.
+type NotificationList struct {
+	// Notifications are the notifications on this page
+	Notifications []Notification `json:"notifications"`
+	// Total is the number of notifications across all pages
+	Total int `json:"total"`
+	// PerPage is the number of notifications per page
+	PerPage int `json:"per_page"`
+	// CurrentPage is the number of this page, starting at 1
+	CurrentPage int `json:"current_page"`
+	// TotalPages is the number of pages
+	TotalPages int `json:"total_pages"`
+	// UnseenCount is the number of notifications the user has not seen
+	UnseenCount int `json:"unseen_count"`
+	// UnreadCount is the number of notifications the user has not read
+	UnreadCount int `json:"unread_count"`
+}
+
+type getNotificationResponse struct {
+	baseResponse
+	Notification *Notification `json:"notification"`
+}
+
+type listNotificationsResponse struct {
+	baseResponse
+	NotificationList
+}
+
+// NotificationsService contains the operations on MagicBell notifications. Use Client.Notifications to access it.
+type NotificationsService struct {
+	api *API
+}
+
+// Create sends a notification to one or multiple users.
+func (s *NotificationsService) Create(ctx context.Context, req CreateNotificationRequest, opts ...CallOption) (*BaseNotification, error) {
+	if err := s.api.validate(req); err != nil {
+		return nil, err
+	}
+
+	content, err := FormatContent(req.ContentFormat, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	req.Content = content
+
+	var out createNotificationResponse
+
+	if err := s.api.makeRequest(ctx, "CreateNotification", http.MethodPost, "notifications", createNotificationRequest{req}, &out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out.Notification, out.Err()
+}
+
+// List returns a page of the user's notifications, newest first.
+func (s *NotificationsService) List(ctx context.Context, user UserIdentity, req ListNotificationsRequest, opts ...CallOption) (*NotificationList, error) {
+	if err := s.api.validate(user); err != nil {
+		return nil, err
+	}
+
+	endpoint := "notifications"
+	if query := req.query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var out listNotificationsResponse
+	opts = append([]CallOption{user.callOption()}, opts...)
+
+	if err := s.api.makeRequest(ctx, "ListNotifications", http.MethodGet, endpoint, nil, &out, opts...); err != nil {
+		return nil, err
+	}
+	if err := out.Err(); err != nil {
+		return nil, err
+	}
+
+	return &out.NotificationList, nil
+}
+
+// Get retrieves one of the user's notifications.
+func (s *NotificationsService) Get(ctx context.Context, user UserIdentity, notificationID string, opts ...CallOption) (*Notification, error) {
+	if err := s.api.validate(user); err != nil {
+		return nil, err
+	}
+
+	var out getNotificationResponse
+	opts = append([]CallOption{user.callOption()}, opts...)
+
+	if err := s.api.makeRequest(ctx, "GetNotification", http.MethodGet, notificationEndpoint(notificationID), nil, &out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out.Notification, out.Err()
+}
+
+// Delete deletes one of the user's notifications.
+func (s *NotificationsService) Delete(ctx context.Context, user UserIdentity, notificationID string, opts ...CallOption) error {
+	return s.userRequest(ctx, "DeleteNotification", http.MethodDelete, notificationEndpoint(notificationID), user, opts)
+}
+
+// MarkRead marks one of the user's notifications as read.
+func (s *NotificationsService) MarkRead(ctx context.Context, user UserIdentity, notificationID string, opts ...CallOption) error {
+	return s.userRequest(ctx, "MarkNotificationRead", http.MethodPost, notificationEndpoint(notificationID)+"/read", user, opts)
+}
+
+// MarkUnread marks one of the user's notifications as unread.
+func (s *NotificationsService) MarkUnread(ctx context.Context, user UserIdentity, notificationID string, opts ...CallOption) error {
+	return s.userRequest(ctx, "MarkNotificationUnread", http.MethodPost, notificationEndpoint(notificationID)+"/unread", user, opts)
+}
+
+// Archive archives one of the user's notifications.
+func (s *NotificationsService) Archive(ctx context.Context, user UserIdentity, notificationID string, opts ...CallOption) error {
+	return s.userRequest(ctx, "ArchiveNotification", http.MethodPost, notificationEndpoint(notificationID)+"/archive", user, opts)
+}
+
+// MarkAllRead marks all of the user's notifications as read.
+func (s *NotificationsService) MarkAllRead(ctx context.Context, user UserIdentity, opts ...CallOption) error {
+	return s.userRequest(ctx, "MarkAllNotificationsRead", http.MethodPost, "notifications/read", user, opts)
+}
+
+// MarkAllSeen marks all of the user's notifications as seen.
+func (s *NotificationsService) MarkAllSeen(ctx context.Context, user UserIdentity, opts ...CallOption) error {
+	return s.userRequest(ctx, "MarkAllNotificationsSeen", http.MethodPost, "notifications/seen", user, opts)
+}
+
+// userRequest makes a request scoped to the user which has no response other than errors.
+func (s *NotificationsService) userRequest(ctx context.Context, operation, method, endpoint string, user UserIdentity, opts []CallOption) error {
+	if err := s.api.validate(user); err != nil {
+		return err
+	}
+
+	var out baseResponse
+	opts = append([]CallOption{user.callOption()}, opts...)
+
+	if err := s.api.makeRequest(ctx, operation, method, endpoint, nil, &out, opts...); err != nil {
+		return err
+	}
+
+	return out.Err()
+}
+
+func notificationEndpoint(notificationID string) string {
+	return "notifications/" + url.PathEscape(notificationID)
 }
 
 // CreateNotification sends a notification to one or multiple users.
@@ -65,16 +316,15 @@ func CreateNotification(req CreateNotificationRequest) (*BaseNotification, error
 
 // CreateNotificationC sends a notification to one or multiple users, using a context.Context in the HTTP request.
 func (a *API) CreateNotificationC(ctx context.Context, req CreateNotificationRequest) (*BaseNotification, error) {
-	var out createNotificationResponse
-
-	if err := a.makeRequest(ctx, http.MethodPost, "notifications", createNotificationRequest{req}, &out); err != nil {
-		return nil, err
-	}
-
-	return out.Notification, out.Err()
+	return a.services.Notifications.Create(ctx, req)
 }
 
 // CreateNotificationC sends a notification to one or multiple users, using a context.Context in the HTTP request.
+// The project named on ctx with WithProject is used, if any.
 func CreateNotificationC(ctx context.Context, req CreateNotificationRequest) (*BaseNotification, error) {
-	return api.CreateNotificationC(ctx, req)
+	projectAPI, err := apiFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return projectAPI.CreateNotificationC(ctx, req)
 }
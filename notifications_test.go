@@ -1,7 +1,10 @@
 package magicbell
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,11 +14,19 @@ import (
 type createNotificationTest struct {
 	name              string
 	httpStatus        int
+	modifyConfigFn    func(*Config)
 	modifyRequestFn   func(*testing.T, *CreateNotificationRequest)
 	checkErr          func(*testing.T, error)
 	checkNotification func(*testing.T, *BaseNotification)
 }
 
+func (test createNotificationTest) Config(config Config) Config {
+	if test.modifyConfigFn != nil {
+		test.modifyConfigFn(&config)
+	}
+	return config
+}
+
 func (test createNotificationTest) Run(t *testing.T, createNotificationFn func(CreateNotificationRequest) (*BaseNotification, error)) {
 	request := initialCreateNotificationRequest
 
@@ -55,6 +66,9 @@ var (
 		{
 			name:       "422",
 			httpStatus: http.StatusUnprocessableEntity,
+			modifyConfigFn: func(config *Config) {
+				config.SkipValidation = true
+			},
 			modifyRequestFn: func(t *testing.T, request *CreateNotificationRequest) {
 				request.Recipients = nil
 			},
@@ -63,6 +77,25 @@ var (
 				require.Nil(t, notification)
 			},
 		},
+		{
+			name:       "invalid request",
+			httpStatus: http.StatusUnprocessableEntity,
+			modifyRequestFn: func(t *testing.T, request *CreateNotificationRequest) {
+				request.Recipients = nil
+			},
+			checkErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.True(t, IsValidationErrors(err))
+				assert.Equal(t, ValidationErrors{{
+					Field:   "notification.recipients",
+					Code:    APIErrorCodeParamMissing,
+					Message: "is missing",
+				}}, err)
+			},
+			checkNotification: func(t *testing.T, notification *BaseNotification) {
+				require.Nil(t, notification)
+			},
+		},
 		{
 			name:       "500",
 			httpStatus: http.StatusInternalServerError,
@@ -94,7 +127,7 @@ func TestAPI_CreateNotification(t *testing.T) {
 	for _, test := range createNotificationTests {
 		t.Run(test.name, func(t *testing.T) {
 			runServer(t, "/notifications", http.MethodPost, test.httpStatus, func(config Config) {
-				api := New(config)
+				api := New(test.Config(config))
 				test.Run(t, api.CreateNotification)
 			})
 		})
@@ -105,11 +138,201 @@ func TestCreateNotification(t *testing.T) {
 	for _, test := range createNotificationTests {
 		t.Run(test.name, func(t *testing.T) {
 			runServer(t, "/notifications", http.MethodPost, test.httpStatus, func(config Config) {
-				runGlobalTest(config, func() {
-					Init(config)
+				runGlobalTest(test.Config(config), func() {
 					test.Run(t, CreateNotification)
 				})
 			})
 		})
 	}
 }
+
+func TestNotificationRecipient_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient NotificationRecipient
+		expected  string
+	}{
+		{
+			name:      "identity only",
+			recipient: NotificationRecipient{Email: "hana@magicbell.io"},
+			expected:  `{"email":"hana@magicbell.io","external_id":""}`,
+		},
+		{
+			name: "with user attributes",
+			recipient: NotificationRecipient{
+				Email:        "hana@magicbell.io",
+				ExternalID:   "56780",
+				FirstName:    "Hana",
+				LastName:     "Mohan",
+				PhoneNumbers: []string{"+15005550001"},
+				CustomAttributes: map[string]interface{}{
+					"plan": "enterprise",
+				},
+			},
+			expected: `{
+				"email": "hana@magicbell.io",
+				"external_id": "56780",
+				"first_name": "Hana",
+				"last_name": "Mohan",
+				"phone_numbers": ["+15005550001"],
+				"custom_attributes": {"plan": "enterprise"}
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded, err := json.Marshal(test.recipient)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(encoded))
+		})
+	}
+}
+
+func TestNotificationsService_Get(t *testing.T) {
+	tests := []struct {
+		name              string
+		httpStatus        int
+		checkErr          func(*testing.T, error)
+		checkNotification func(*testing.T, *Notification)
+	}{
+		{
+			name:       "200",
+			httpStatus: http.StatusOK,
+			checkErr:   assertNoError,
+			checkNotification: func(t *testing.T, notification *Notification) {
+				require.NotNil(t, notification)
+				assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", notification.ID)
+				assert.Equal(t, "Welcome to MagicBell", notification.Title)
+				require.NotNil(t, notification.SeenAt)
+				assert.Equal(t, int64(1612818090), notification.SeenAt.Unix())
+				assert.Nil(t, notification.ReadAt)
+			},
+		},
+		{
+			name:       "403",
+			httpStatus: http.StatusForbidden,
+			checkErr:   assertAPIError(APIErrorCodeForbidden, "notification not found"),
+			checkNotification: func(t *testing.T, notification *Notification) {
+				assert.Nil(t, notification)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, "/notifications/ffffff66-ea4f-4da2-afc6-84148b51657a", http.MethodGet, test.httpStatus, func(config Config) {
+				client := NewClient(WithConfig(config))
+				notification, err := client.Notifications.Get(context.Background(), UserIdentity{Email: "hana@magicbell.io"}, "ffffff66-ea4f-4da2-afc6-84148b51657a")
+				test.checkErr(t, err)
+				test.checkNotification(t, notification)
+			})
+		})
+	}
+}
+
+func TestNotificationsService_UserRequests(t *testing.T) {
+	const id = "ffffff66-ea4f-4da2-afc6-84148b51657a"
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		fn     func(context.Context, *NotificationsService, UserIdentity) error
+	}{
+		{
+			name:   "delete",
+			method: http.MethodDelete,
+			path:   "/notifications/" + id,
+			fn: func(ctx context.Context, s *NotificationsService, user UserIdentity) error {
+				return s.Delete(ctx, user, id)
+			},
+		},
+		{
+			name:   "mark read",
+			method: http.MethodPost,
+			path:   "/notifications/" + id + "/read",
+			fn: func(ctx context.Context, s *NotificationsService, user UserIdentity) error {
+				return s.MarkRead(ctx, user, id)
+			},
+		},
+		{
+			name:   "mark unread",
+			method: http.MethodPost,
+			path:   "/notifications/" + id + "/unread",
+			fn: func(ctx context.Context, s *NotificationsService, user UserIdentity) error {
+				return s.MarkUnread(ctx, user, id)
+			},
+		},
+		{
+			name:   "archive",
+			method: http.MethodPost,
+			path:   "/notifications/" + id + "/archive",
+			fn: func(ctx context.Context, s *NotificationsService, user UserIdentity) error {
+				return s.Archive(ctx, user, id)
+			},
+		},
+		{
+			name:   "mark all read",
+			method: http.MethodPost,
+			path:   "/notifications/read",
+			fn: func(ctx context.Context, s *NotificationsService, user UserIdentity) error {
+				return s.MarkAllRead(ctx, user)
+			},
+		},
+		{
+			name:   "mark all seen",
+			method: http.MethodPost,
+			path:   "/notifications/seen",
+			fn: func(ctx context.Context, s *NotificationsService, user UserIdentity) error {
+				return s.MarkAllSeen(ctx, user)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, status := range []int{http.StatusNoContent, http.StatusOK} {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, test.method, r.Method)
+					assert.Equal(t, test.path, r.URL.Path)
+					assert.Equal(t, "56780", r.Header.Get(userExternalIDHeader))
+					w.WriteHeader(status)
+				}))
+
+				client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+				assert.NoError(t, test.fn(context.Background(), client.Notifications, UserIdentity{ExternalID: "56780"}), "status %d", status)
+				srv.Close()
+			}
+
+			client := NewClient(WithConfig(validConfig))
+			err := test.fn(context.Background(), client.Notifications, UserIdentity{})
+			assert.True(t, IsValidationErrors(err))
+		})
+	}
+}
+
+func TestNotificationsService_Create_ContentFormatAlias(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Notification struct {
+				Content string `json:"content"`
+			} `json:"notification"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "<p><strong>Hi</strong></p>", body.Notification.Content)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"notification":{"id":"ffffff66-ea4f-4da2-afc6-84148b51657a"}}`))
+	}))
+	defer srv.Close()
+
+	request := initialCreateNotificationRequest
+	request.Content = "**Hi**"
+	request.ContentFormat = "md"
+
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	notification, err := client.Notifications.Create(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", notification.ID)
+}
@@ -0,0 +1,114 @@
+// Package prometheus provides a magicbell.Metrics implementation which exposes
+// MagicBell API request metrics as Prometheus metrics.
+//
+//	collector := mbprometheus.NewCollector(mbprometheus.Options{})
+//	prometheus.MustRegister(collector)
+//	magicbell.Init(magicbell.Config{
+//		APIKey:    "my-key",
+//		APISecret: "my-secret",
+//		Metrics:   collector,
+//	})
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+const defaultNamespace = "magicbell"
+
+// Options configures the metrics created by NewCollector.
+type Options struct {
+	// Namespace is the prefix of all metric names. If not provided, it will default to magicbell.
+	Namespace string
+	// ConstLabels are labels with fixed values added to all metrics, this is optional.
+	ConstLabels prom.Labels
+	// Buckets are the latency histogram buckets in seconds. If not provided, it will default to prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Collector implements both magicbell.Metrics and prometheus.Collector. It exposes:
+//
+//	magicbell_requests_total{operation,status,error_code}                counter
+//	magicbell_request_duration_seconds{operation,status}                 histogram
+//	magicbell_requests_in_flight{operation}                              gauge
+//
+// The status label is the HTTP status code, or "error" when no HTTP response was received.
+// Use NewCollector to instantiate this struct.
+type Collector struct {
+	requests *prom.CounterVec
+	duration *prom.HistogramVec
+	inFlight *prom.GaugeVec
+}
+
+var _ magicbell.Metrics = (*Collector)(nil)
+var _ prom.Collector = (*Collector)(nil)
+
+// NewCollector instantiates a new Collector. It must be registered with a
+// prometheus.Registerer for its metrics to be exported.
+func NewCollector(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = defaultNamespace
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prom.DefBuckets
+	}
+
+	return &Collector{
+		requests: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_total",
+			Help:        "Total number of MagicBell API requests by operation, HTTP status and API error code.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"operation", "status", "error_code"}),
+		duration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of MagicBell API requests by operation and HTTP status.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.Buckets,
+		}, []string{"operation", "status"}),
+		inFlight: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_in_flight",
+			Help:        "Number of MagicBell API requests currently in flight by operation.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"operation"}),
+	}
+}
+
+// RequestStarted implements magicbell.Metrics by incrementing the in-flight gauge.
+func (c *Collector) RequestStarted(operation string) {
+	c.inFlight.WithLabelValues(operation).Inc()
+}
+
+// RequestFinished implements magicbell.Metrics by decrementing the in-flight gauge,
+// incrementing the request counter and observing the request latency.
+func (c *Collector) RequestFinished(operation string, statusCode int, errorCode magicbell.APIErrorCode, duration time.Duration) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+
+	c.inFlight.WithLabelValues(operation).Dec()
+	c.requests.WithLabelValues(operation, status, string(errorCode)).Inc()
+	c.duration.WithLabelValues(operation, status).Observe(duration.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.inFlight.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.inFlight.Collect(ch)
+}
@@ -0,0 +1,64 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	magicbell "github.com/tizz98/magicbell-go"
+)
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector(Options{Buckets: []float64{1}})
+
+	collector.RequestStarted("CreateUser")
+	collector.RequestStarted("CreateNotification")
+	collector.RequestFinished("CreateNotification", 201, "", 100*time.Millisecond)
+
+	expected := `
+# HELP magicbell_requests_in_flight Number of MagicBell API requests currently in flight by operation.
+# TYPE magicbell_requests_in_flight gauge
+magicbell_requests_in_flight{operation="CreateNotification"} 0
+magicbell_requests_in_flight{operation="CreateUser"} 1
+# HELP magicbell_requests_total Total number of MagicBell API requests by operation, HTTP status and API error code.
+# TYPE magicbell_requests_total counter
+magicbell_requests_total{error_code="",operation="CreateNotification",status="201"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "magicbell_requests_in_flight", "magicbell_requests_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "magicbell_request_duration_seconds"))
+}
+
+func TestCollector_WithAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"forbidden","message":"not allowed"}]}`))
+	}))
+	defer srv.Close()
+
+	registry := prom.NewPedanticRegistry()
+	collector := NewCollector(Options{Namespace: "test", ConstLabels: prom.Labels{"project": "dev"}})
+	require.NoError(t, registry.Register(collector))
+
+	api := magicbell.New(magicbell.Config{
+		APIKey:    "key",
+		APISecret: "secret",
+		BaseURL:   srv.URL,
+		Metrics:   collector,
+	})
+	_, err := api.CreateUser(magicbell.CreateUserRequest{Email: "hana@magicbell.io"})
+	require.Error(t, err)
+
+	expected := `
+# HELP test_requests_total Total number of MagicBell API requests by operation, HTTP status and API error code.
+# TYPE test_requests_total counter
+test_requests_total{error_code="forbidden",operation="CreateUser",project="dev",status="403"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "test_requests_total"))
+}
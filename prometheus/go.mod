@@ -0,0 +1,11 @@
+module github.com/tizz98/magicbell-go/prometheus
+
+go 1.15
+
+require (
+	github.com/prometheus/client_golang v1.9.0
+	github.com/stretchr/testify v1.7.0
+	github.com/tizz98/magicbell-go v0.0.0
+)
+
+replace github.com/tizz98/magicbell-go => ../
@@ -0,0 +1,107 @@
+package magicbell
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type projectContextKey struct{}
+
+// Registry holds named IAPI instances, one per MagicBell project, for services which send
+// notifications for several projects, for example one per product or region.
+// It is safe for concurrent use. Use NewRegistry to instantiate this struct.
+type Registry struct {
+	mu          sync.RWMutex
+	projects    map[string]IAPI
+	defaultName string
+}
+
+// NewRegistry instantiates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{projects: map[string]IAPI{}}
+}
+
+// Register stores the IAPI under the project name, replacing any IAPI previously registered with the same name.
+// The first project registered becomes the default project.
+func (r *Registry) Register(name string, api IAPI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects[name] = api
+	if r.defaultName == "" {
+		r.defaultName = name
+	}
+}
+
+// SetDefault makes the registered project the default project, which is used by Resolve
+// when the context.Context does not name a project.
+func (r *Registry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[name]; !ok {
+		return projectNotRegisteredError(name)
+	}
+	r.defaultName = name
+	return nil
+}
+
+// Get returns the IAPI registered under the project name.
+func (r *Registry) Get(name string) (IAPI, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	api, ok := r.projects[name]
+	if !ok {
+		return nil, projectNotRegisteredError(name)
+	}
+	return api, nil
+}
+
+// Resolve returns the IAPI of the project set on the context.Context with WithProject,
+// or the default project if the context.Context does not name a project.
+func (r *Registry) Resolve(ctx context.Context) (IAPI, error) {
+	if name, ok := ProjectFromContext(ctx); ok {
+		return r.Get(name)
+	}
+
+	r.mu.RLock()
+	name := r.defaultName
+	r.mu.RUnlock()
+
+	if name == "" {
+		return nil, fmt.Errorf("magicbell-go/registry: no projects are registered")
+	}
+	return r.Get(name)
+}
+
+// Names returns the names of all registered projects, sorted alphabetically.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.projects))
+	for name := range r.projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProject returns a copy of ctx naming the project that Registry.Resolve and
+// the global shortcuts accepting a context.Context should use.
+func WithProject(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, projectContextKey{}, name)
+}
+
+// ProjectFromContext returns the project name set on ctx with WithProject, if any.
+func ProjectFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(projectContextKey{}).(string)
+	return name, ok
+}
+
+func projectNotRegisteredError(name string) error {
+	return fmt.Errorf("magicbell-go/registry: project %q is not registered", name)
+}
@@ -0,0 +1,84 @@
+package magicbell
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	_, err := registry.Resolve(context.Background())
+	assert.EqualError(t, err, "magicbell-go/registry: no projects are registered")
+
+	us := New(Config{APIKey: "us"})
+	eu := New(Config{APIKey: "eu"})
+	registry.Register("us", us)
+	registry.Register("eu", eu)
+
+	assert.Equal(t, []string{"eu", "us"}, registry.Names())
+
+	resolved, err := registry.Get("eu")
+	require.NoError(t, err)
+	assert.Same(t, eu, resolved)
+
+	_, err = registry.Get("apac")
+	assert.EqualError(t, err, `magicbell-go/registry: project "apac" is not registered`)
+
+	resolved, err = registry.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, us, resolved, "first registered project is the default")
+
+	resolved, err = registry.Resolve(WithProject(context.Background(), "eu"))
+	require.NoError(t, err)
+	assert.Same(t, eu, resolved)
+
+	_, err = registry.Resolve(WithProject(context.Background(), "apac"))
+	assert.Error(t, err)
+
+	assert.Error(t, registry.SetDefault("apac"))
+	require.NoError(t, registry.SetDefault("eu"))
+	resolved, err = registry.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, eu, resolved)
+}
+
+func TestProjectFromContext(t *testing.T) {
+	_, ok := ProjectFromContext(context.Background())
+	assert.False(t, ok)
+
+	name, ok := ProjectFromContext(WithProject(context.Background(), "eu"))
+	assert.True(t, ok)
+	assert.Equal(t, "eu", name)
+}
+
+func TestInitProject(t *testing.T) {
+	runServer(t, "/notifications", http.MethodPost, http.StatusCreated, func(config Config) {
+		runGlobalTest(Config{APIKey: "unused", BaseURL: "http://127.0.0.1:0"}, func() {
+			InitProject("eu", config)
+
+			assert.Equal(t, []string{"eu"}, Projects().Names())
+			assert.NotNil(t, Project("eu"))
+			assert.Panics(t, func() { Project("apac") })
+
+			notification, err := CreateNotificationC(WithProject(context.Background(), "eu"), initialCreateNotificationRequest)
+			require.NoError(t, err)
+			assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", notification.ID)
+
+			notification, err = Project("eu").CreateNotification(initialCreateNotificationRequest)
+			require.NoError(t, err)
+			assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", notification.ID)
+
+			_, err = CreateUserC(WithProject(context.Background(), "apac"), initialCreateUserRequest)
+			assert.EqualError(t, err, `magicbell-go/registry: project "apac" is not registered`)
+
+			// without a project on the context, the API initialized with Init is used
+			_, err = CreateNotificationC(context.Background(), initialCreateNotificationRequest)
+			assert.Error(t, err)
+		})
+	})
+}
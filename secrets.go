@@ -0,0 +1,76 @@
+package magicbell
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Prefixes of secret references resolved by ResolveSecret.
+const (
+	SecretRefFile = "file:"
+	SecretRefEnv  = "env:"
+	SecretRefExec = "exec:"
+)
+
+// ResolveSecret returns the value of a secret reference, so secrets do not have to be stored in plain text:
+//
+//	file:/run/secrets/magicbell   the contents of the file, without trailing whitespace
+//	env:MY_MAGICBELL_SECRET       the value of the environment variable, which must be set
+//	exec:credential-helper get    the output of the command, without trailing whitespace
+//
+// Commands are run directly, not through a shell. Any other value is returned as-is.
+// An exec: reference runs whatever command it names, so only resolve references from configuration
+// as trusted as the program itself, never from files or input controlled by someone else.
+func ResolveSecret(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, SecretRefFile):
+		path := strings.TrimPrefix(ref, SecretRefFile)
+		data, err := ioutil.ReadFile(path) // #nosec G304
+		if err != nil {
+			return "", fmt.Errorf("magicbell-go/secrets: unable to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), " \t\r\n"), nil
+	case strings.HasPrefix(ref, SecretRefEnv):
+		name := strings.TrimPrefix(ref, SecretRefEnv)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("magicbell-go/secrets: environment variable %s is not set", name)
+		}
+		return value, nil
+	case strings.HasPrefix(ref, SecretRefExec):
+		args := strings.Fields(strings.TrimPrefix(ref, SecretRefExec))
+		if len(args) == 0 {
+			return "", fmt.Errorf("magicbell-go/secrets: no command given to %s", SecretRefExec)
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(args[0], args[1:]...) // #nosec G204 -- the command is configured by the user
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("magicbell-go/secrets: command %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		return strings.TrimRight(stdout.String(), " \t\r\n"), nil
+	default:
+		return ref, nil
+	}
+}
+
+// ResolveSecrets returns a copy of the Config with the APIKey and APISecret secret references resolved.
+// See ResolveSecret for the supported references.
+func (c Config) ResolveSecrets() (Config, error) {
+	var err error
+
+	if c.APIKey, err = ResolveSecret(c.APIKey); err != nil {
+		return Config{}, fmt.Errorf("magicbell-go/config: unable to resolve APIKey: %w", err)
+	}
+	if c.APISecret, err = ResolveSecret(c.APISecret); err != nil {
+		return Config{}, fmt.Errorf("magicbell-go/config: unable to resolve APISecret: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,98 @@
+package magicbell
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "magicbell-secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(secretPath, []byte("from-file\n"), 0600))
+	setEnv(t, "MAGICBELL_TEST_SECRET", "from-env")
+
+	tests := []struct {
+		name        string
+		ref         string
+		expected    string
+		expectedErr string
+		needsPOSIX  bool
+	}{
+		{name: "literal", ref: "plain-secret", expected: "plain-secret"},
+		{name: "file", ref: "file:" + secretPath, expected: "from-file"},
+		{name: "missing file", ref: "file:" + filepath.Join(dir, "missing"), expectedErr: "magicbell-go/secrets: unable to read secret file"},
+		{name: "env", ref: "env:MAGICBELL_TEST_SECRET", expected: "from-env"},
+		{name: "missing env", ref: "env:MAGICBELL_TEST_MISSING", expectedErr: "magicbell-go/secrets: environment variable MAGICBELL_TEST_MISSING is not set"},
+		{name: "exec", ref: "exec:echo from-exec", expected: "from-exec", needsPOSIX: true},
+		{name: "failed exec", ref: "exec:false", expectedErr: "magicbell-go/secrets: command false failed", needsPOSIX: true},
+		{name: "empty exec", ref: "exec: ", expectedErr: "magicbell-go/secrets: no command given to exec:"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.needsPOSIX && runtime.GOOS == "windows" {
+				t.Skip("requires POSIX commands")
+			}
+
+			secret, err := ResolveSecret(test.ref)
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, secret)
+		})
+	}
+}
+
+func TestConfig_ResolveSecrets(t *testing.T) {
+	setEnv(t, "MAGICBELL_TEST_SECRET", "from-env")
+
+	config, err := Config{APIKey: "my-key", APISecret: "env:MAGICBELL_TEST_SECRET"}.ResolveSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, Config{APIKey: "my-key", APISecret: "from-env"}, config)
+
+	_, err = Config{APIKey: "env:MAGICBELL_TEST_MISSING"}.ResolveSecrets()
+	assert.EqualError(t, err, "magicbell-go/config: unable to resolve APIKey: magicbell-go/secrets: environment variable MAGICBELL_TEST_MISSING is not set")
+}
+
+func TestConfigFromEnv_SecretReference(t *testing.T) {
+	setEnv(t, "MAGICBELL_TEST_SECRET", "from-env")
+	setEnv(t, EnvAPIKey, "my-key")
+	setEnv(t, EnvAPISecret, "env:MAGICBELL_TEST_SECRET")
+
+	config, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "env:MAGICBELL_TEST_SECRET", config.APISecret)
+
+	config, err = config.ResolveSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", config.APISecret)
+}
+
+func TestLoadConfig_ExecSecretNotRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "magicbell-secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	marker := filepath.Join(dir, "ran")
+	path := filepath.Join(dir, "magicbell.yaml")
+	ref := "exec:touch " + marker
+	require.NoError(t, ioutil.WriteFile(path, []byte("api_key: my-key\napi_secret: "+ref+"\n"), 0600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, ref, config.APISecret)
+	assert.NoFileExists(t, marker)
+}
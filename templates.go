@@ -0,0 +1,160 @@
+package magicbell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sync"
+	texttemplate "text/template"
+)
+
+// NotificationTemplate is a named set of Go templates used to render notifications.
+// Title and ActionURL are rendered with text/template, Content is rendered with html/template
+// so any data inserted into it is safely HTML escaped. All templates are executed with the
+// notification's CustomAttributes as data and referencing a missing key is an error.
+type NotificationTemplate struct {
+	// Title is the template for the title of the notification, this is required.
+	Title string
+	// Content is the template for the content of the notification, this is optional.
+	Content string
+	// ContentFormat is the format the rendered Content is written in, this is optional.
+	// See CreateNotificationRequest.ContentFormat.
+	ContentFormat ContentFormat
+	// ActionURL is the template for the URL to redirect the user to when they click on the notification, this is optional.
+	ActionURL string
+	// Category is the category the rendered notifications belong to, this is optional.
+	Category string
+}
+
+type compiledTemplate struct {
+	title         *texttemplate.Template
+	content       *htmltemplate.Template
+	actionURL     *texttemplate.Template
+	category      string
+	contentFormat ContentFormat
+}
+
+// TemplateRegistry holds named NotificationTemplates. It is safe for concurrent use.
+// Use NewTemplateRegistry to instantiate this struct.
+type TemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]compiledTemplate
+}
+
+// NewTemplateRegistry instantiates an empty TemplateRegistry.
+func NewTemplateRegistry() *TemplateRegistry {
+	return &TemplateRegistry{templates: map[string]compiledTemplate{}}
+}
+
+// Register parses the NotificationTemplate and stores it under name,
+// replacing any template previously registered with the same name.
+func (r *TemplateRegistry) Register(name string, tmpl NotificationTemplate) error {
+	if tmpl.Title == "" {
+		return fmt.Errorf("magicbell-go/templates: template %q must have a title", name)
+	}
+
+	compiled := compiledTemplate{
+		category:      tmpl.Category,
+		contentFormat: tmpl.ContentFormat,
+	}
+
+	var err error
+	if compiled.title, err = texttemplate.New(name + ".title").Option("missingkey=error").Parse(tmpl.Title); err != nil {
+		return fmt.Errorf("magicbell-go/templates: error parsing title of %q: %w", name, err)
+	}
+	if compiled.content, err = htmltemplate.New(name + ".content").Option("missingkey=error").Parse(tmpl.Content); err != nil {
+		return fmt.Errorf("magicbell-go/templates: error parsing content of %q: %w", name, err)
+	}
+	if compiled.actionURL, err = texttemplate.New(name + ".action_url").Option("missingkey=error").Parse(tmpl.ActionURL); err != nil {
+		return fmt.Errorf("magicbell-go/templates: error parsing action url of %q: %w", name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[name] = compiled
+	return nil
+}
+
+// Render executes the template registered under name with data and returns a CreateNotificationRequest
+// for the recipients. The data is also attached to the request as its CustomAttributes.
+func (r *TemplateRegistry) Render(name string, recipients []NotificationRecipient, data CustomAttributes) (CreateNotificationRequest, error) {
+	r.mu.RLock()
+	compiled, ok := r.templates[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return CreateNotificationRequest{}, fmt.Errorf("magicbell-go/templates: template %q is not registered", name)
+	}
+
+	req := CreateNotificationRequest{
+		Recipients:       recipients,
+		CustomAttributes: data,
+		Category:         compiled.category,
+		ContentFormat:    compiled.contentFormat,
+	}
+
+	var err error
+	if req.Title, err = executeTemplate(compiled.title, data); err != nil {
+		return CreateNotificationRequest{}, fmt.Errorf("magicbell-go/templates: error rendering title of %q: %w", name, err)
+	}
+	if req.Content, err = executeTemplate(compiled.content, data); err != nil {
+		return CreateNotificationRequest{}, fmt.Errorf("magicbell-go/templates: error rendering content of %q: %w", name, err)
+	}
+	if req.ActionURL, err = executeTemplate(compiled.actionURL, data); err != nil {
+		return CreateNotificationRequest{}, fmt.Errorf("magicbell-go/templates: error rendering action url of %q: %w", name, err)
+	}
+
+	return req, nil
+}
+
+func executeTemplate(tmpl interface {
+	Execute(w io.Writer, data interface{}) error
+}, data CustomAttributes) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}(data)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RegisterTemplate parses the NotificationTemplate and stores it under name
+// for use with CreateNotificationFromTemplate.
+func (a *API) RegisterTemplate(name string, tmpl NotificationTemplate) error {
+	return a.config.Templates.Register(name, tmpl)
+}
+
+// RegisterTemplate is a global shortcut to API.RegisterTemplate
+func RegisterTemplate(name string, tmpl NotificationTemplate) error {
+	return api.RegisterTemplate(name, tmpl)
+}
+
+// CreateNotificationFromTemplate renders the template registered under name with data and
+// sends the resulting notification to the recipients, using a context.Context in the HTTP request.
+// The data is also attached to the notification as its CustomAttributes.
+func (a *API) CreateNotificationFromTemplate(ctx context.Context, name string, recipients []NotificationRecipient, data CustomAttributes) (*BaseNotification, error) {
+	return a.services.Notifications.CreateFromTemplate(ctx, name, recipients, data)
+}
+
+// CreateFromTemplate renders the template registered under name in Client.Templates with data
+// and sends the resulting notification to the recipients.
+// The data is also attached to the notification as its CustomAttributes.
+func (s *NotificationsService) CreateFromTemplate(ctx context.Context, name string, recipients []NotificationRecipient, data CustomAttributes, opts ...CallOption) (*BaseNotification, error) {
+	req, err := s.api.config.Templates.Render(name, recipients, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Create(ctx, req, opts...)
+}
+
+// CreateNotificationFromTemplate is a global shortcut to API.CreateNotificationFromTemplate.
+// The project named on ctx with WithProject is used, if any.
+func CreateNotificationFromTemplate(ctx context.Context, name string, recipients []NotificationRecipient, data CustomAttributes) (*BaseNotification, error) {
+	projectAPI, err := apiFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return projectAPI.CreateNotificationFromTemplate(ctx, name, recipients, data)
+}
@@ -0,0 +1,119 @@
+package magicbell
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var orderShippedTemplate = NotificationTemplate{
+	Title:     "Order {{.order.id}} shipped",
+	Content:   "<p>Hi {{.name}}, your order is on its way!</p>",
+	ActionURL: "https://example.com/orders/{{.order.id | urlquery}}",
+	Category:  "order_shipped",
+}
+
+func TestTemplateRegistry_Render(t *testing.T) {
+	recipients := []NotificationRecipient{{Email: "hana@magicbell.io"}}
+
+	tests := []struct {
+		name        string
+		data        CustomAttributes
+		template    string
+		expected    CreateNotificationRequest
+		expectedErr string
+	}{
+		{
+			name:     "happy case",
+			template: "order_shipped",
+			data: CustomAttributes{
+				"name":  "<b>Hana</b>",
+				"order": map[string]interface{}{"id": "123 45"},
+			},
+			expected: CreateNotificationRequest{
+				Title:      "Order 123 45 shipped",
+				Recipients: recipients,
+				Content:    "<p>Hi &lt;b&gt;Hana&lt;/b&gt;, your order is on its way!</p>",
+				ActionURL:  "https://example.com/orders/123+45",
+				Category:   "order_shipped",
+				CustomAttributes: CustomAttributes{
+					"name":  "<b>Hana</b>",
+					"order": map[string]interface{}{"id": "123 45"},
+				},
+			},
+		},
+		{
+			name:     "missing key",
+			template: "order_shipped",
+			data: CustomAttributes{
+				"order": map[string]interface{}{"id": "12345"},
+			},
+			expectedErr: `magicbell-go/templates: error rendering content of "order_shipped"`,
+		},
+		{
+			name:        "unknown template",
+			template:    "unknown",
+			expectedErr: `magicbell-go/templates: template "unknown" is not registered`,
+		},
+	}
+
+	registry := NewTemplateRegistry()
+	require.NoError(t, registry.Register("order_shipped", orderShippedTemplate))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := registry.Render(test.template, recipients, test.data)
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, req)
+		})
+	}
+}
+
+func TestTemplateRegistry_Register(t *testing.T) {
+	registry := NewTemplateRegistry()
+
+	assert.EqualError(t, registry.Register("empty", NotificationTemplate{}), `magicbell-go/templates: template "empty" must have a title`)
+	assert.Error(t, registry.Register("invalid", NotificationTemplate{Title: "{{.unclosed"}))
+}
+
+func TestAPI_CreateNotificationFromTemplate(t *testing.T) {
+	runServer(t, "/notifications", http.MethodPost, http.StatusCreated, func(config Config) {
+		api := New(config)
+		require.NoError(t, api.RegisterTemplate("order_shipped", orderShippedTemplate))
+
+		notification, err := api.CreateNotificationFromTemplate(context.Background(), "order_shipped", []NotificationRecipient{{Email: "hana@magicbell.io"}}, CustomAttributes{
+			"name":  "Hana",
+			"order": map[string]interface{}{"id": "12345"},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, notification)
+		assert.Equal(t, "ffffff66-ea4f-4da2-afc6-84148b51657a", notification.ID)
+	})
+}
+
+func TestCreateNotificationFromTemplate(t *testing.T) {
+	runServer(t, "/notifications", http.MethodPost, http.StatusCreated, func(config Config) {
+		runGlobalTest(config, func() {
+			require.NoError(t, RegisterTemplate("order_shipped", orderShippedTemplate))
+
+			_, err := CreateNotificationFromTemplate(context.Background(), "missing", nil, nil)
+			assert.EqualError(t, err, `magicbell-go/templates: template "missing" is not registered`)
+
+			notification, err := CreateNotificationFromTemplate(context.Background(), "order_shipped", []NotificationRecipient{{Email: "hana@magicbell.io"}}, CustomAttributes{
+				"name":  "Hana",
+				"order": map[string]interface{}{"id": "12345"},
+			})
+			require.NoError(t, err)
+			require.NotNil(t, notification)
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package magicbell
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time returned by the MagicBell API, which represents times as the number of
+// seconds since the Unix epoch. RFC 3339 strings are accepted as well when decoding.
+type Timestamp struct {
+	time.Time
+}
+
+// MarshalJSON encodes the Timestamp as the number of seconds since the Unix epoch.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
+
+// UnmarshalJSON decodes a number of seconds since the Unix epoch, or an RFC 3339 string, into the Timestamp.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("magicbell-go/api: invalid timestamp %q: %w", s, err)
+		}
+		t.Time = parsed
+		return nil
+	}
+
+	seconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("magicbell-go/api: invalid timestamp %s: %w", data, err)
+	}
+	whole, frac := math.Modf(seconds)
+	t.Time = time.Unix(int64(whole), int64(frac*float64(time.Second))).UTC()
+	return nil
+}
@@ -0,0 +1,94 @@
+package magicbell
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tizz98/magicbell-go/version"
+)
+
+const (
+	tracerName = "github.com/tizz98/magicbell-go"
+
+	operationKey       = attribute.Key("magicbell.operation")
+	recipientsCountKey = attribute.Key("magicbell.recipients.count")
+	errorCodesKey      = attribute.Key("magicbell.error_codes")
+)
+
+// newTracer returns the tracer and propagator to use for the Config. Tracing is a no-op
+// unless Config.TracerProvider is set, in which case Config.Propagator defaults to the global propagator.
+func newTracer(config Config) (trace.Tracer, propagation.TextMapPropagator) {
+	if config.TracerProvider == nil {
+		return trace.NewNoopTracerProvider().Tracer(tracerName), propagation.NewCompositeTextMapPropagator()
+	}
+
+	propagator := config.Propagator
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+	return config.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(version.BuildVersion)), propagator
+}
+
+// traceOperation is a Middleware which wraps the Operation in a client span named after
+// the IAPI method, for example CreateNotification, and propagates the trace context in its headers.
+func (a *API) traceOperation(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) error {
+		attrs := []attribute.KeyValue{
+			operationKey.String(op.Name),
+			semconv.HTTPMethodKey.String(op.Method),
+			semconv.HTTPURLKey.String(a.operationURL(op)),
+		}
+		if count, ok := recipientsCount(op.Request); ok {
+			attrs = append(attrs, recipientsCountKey.Int(count))
+		}
+
+		ctx, span := a.tracer.Start(ctx, op.Name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		a.propagator.Inject(ctx, propagation.HeaderCarrier(op.Header))
+
+		err := next(ctx, op)
+		endSpan(span, op.StatusCode, responseError(op.Response, err))
+		return err
+	}
+}
+
+// endSpan records the outcome of the API request on the span and ends it.
+func endSpan(span trace.Span, statusCode int, err error) {
+	defer span.End()
+
+	if statusCode != 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	}
+	if err == nil {
+		return
+	}
+
+	if errCodes := errorCodes(err); len(errCodes) > 0 {
+		span.SetAttributes(errorCodesKey.StringSlice(errCodes))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// responseError returns err, or the errors decoded into the response when the request itself succeeded.
+func responseError(out interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	if r, ok := out.(interface{ Err() error }); ok {
+		return r.Err()
+	}
+	return nil
+}
+
+func recipientsCount(requestBody interface{}) (int, bool) {
+	if req, ok := requestBody.(createNotificationRequest); ok {
+		return len(req.Notification.Recipients), true
+	}
+	return 0, false
+}
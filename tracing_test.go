@@ -0,0 +1,113 @@
+package magicbell
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func runTracedServer(t *testing.T, path string, method string, status int, fn func(config Config, recorder *tracetest.SpanRecorder)) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	runServer(t, path, method, status, func(config Config) {
+		config.TracerProvider = provider
+		config.Propagator = propagation.TraceContext{}
+		fn(config, recorder)
+	})
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestAPI_Tracing(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		runTracedServer(t, "/notifications", http.MethodPost, http.StatusCreated, func(config Config, recorder *tracetest.SpanRecorder) {
+			_, err := New(config).CreateNotification(initialCreateNotificationRequest)
+			require.NoError(t, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "CreateNotification", spans[0].Name())
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+			attrs := spanAttributes(spans[0])
+			assert.Equal(t, "CreateNotification", attrs["magicbell.operation"].AsString())
+			assert.Equal(t, "POST", attrs["http.method"].AsString())
+			assert.Equal(t, int64(201), attrs["http.status_code"].AsInt64())
+			assert.Equal(t, int64(1), attrs["magicbell.recipients.count"].AsInt64())
+		})
+	})
+
+	t.Run("api errors", func(t *testing.T) {
+		runTracedServer(t, "/users", http.MethodPost, http.StatusBadRequest, func(config Config, recorder *tracetest.SpanRecorder) {
+			_, err := New(config).CreateUser(initialCreateUserRequest)
+			require.Error(t, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "CreateUser", spans[0].Name())
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+			attrs := spanAttributes(spans[0])
+			assert.Equal(t, int64(400), attrs["http.status_code"].AsInt64())
+			assert.Equal(t, []string{"user_email_not_provided"}, attrs["magicbell.error_codes"].AsStringSlice())
+			_, ok := attrs["magicbell.recipients.count"]
+			assert.False(t, ok)
+		})
+	})
+}
+
+func TestAPI_TracingPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	config := validConfig.withBaseURL(srv.URL)
+	config.TracerProvider = provider
+	config.Propagator = propagation.TraceContext{}
+
+	_, err := New(config).UpdateUserC(context.Background(), "email:hana@magicbell.io", initialUpdateUserRequest)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "UpdateUser", spans[0].Name())
+	assert.Contains(t, traceparent, spans[0].SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, spans[0].SpanContext().SpanID().String())
+}
+
+func TestAPI_TracingDisabled(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	_, err := New(validConfig.withBaseURL(srv.URL)).UpdateUser("email:hana@magicbell.io", initialUpdateUserRequest)
+	require.NoError(t, err)
+	assert.Empty(t, traceparent)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // CreateUserRequest is the set of data required to create a new user in MagicBell.
@@ -68,6 +70,49 @@ type createUserResponse struct {
 	User *User `json:"user"`
 }
 
+type getUserResponse struct {
+	baseResponse
+	User *User `json:"user"`
+}
+
+// ListUsersRequest paginates the users returned by UsersService.List. All fields are optional.
+type ListUsersRequest struct {
+	// Page is the page of users to return, starting at 1
+	Page int
+	// PerPage is the number of users per page
+	PerPage int
+}
+
+func (r ListUsersRequest) query() url.Values {
+	query := url.Values{}
+	if r.Page > 0 {
+		query.Set("page", strconv.Itoa(r.Page))
+	}
+	if r.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(r.PerPage))
+	}
+	return query
+}
+
+// UserList is a page of the users of a project.
+type UserList struct {
+	// Users are the users on this page
+	Users []User `json:"users"`
+	// Total is the number of users across all pages
+	Total int `json:"total"`
+	// PerPage is the number of users per page
+	PerPage int `json:"per_page"`
+	// CurrentPage is the number of this page, starting at 1
+	CurrentPage int `json:"current_page"`
+	// TotalPages is the number of pages
+	TotalPages int `json:"total_pages"`
+}
+
+type listUsersResponse struct {
+	baseResponse
+	UserList
+}
+
 type updateUserRequest struct {
 	User UpdateUserRequest `json:"user"`
 }
@@ -77,6 +122,108 @@ type updateUserResponse struct {
 	User *User `json:"user"`
 }
 
+// UsersService contains the operations on MagicBell users. Use Client.Users to access it.
+type UsersService struct {
+	api *API
+}
+
+// Create creates a new user in MagicBell.
+// Please note that you must provide the user's email or the external id so MagicBell can uniquely identify the user.
+// The external id, if provided, must be unique to the user.
+func (s *UsersService) Create(ctx context.Context, req CreateUserRequest, opts ...CallOption) (*User, error) {
+	if err := s.api.validate(req); err != nil {
+		return nil, err
+	}
+
+	var out createUserResponse
+
+	if err := s.api.makeRequest(ctx, "CreateUser", http.MethodPost, "users", createUserRequest{req}, &out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out.User, out.Err()
+}
+
+// Update updates a user in MagicBell with the given ID.
+// The user id is the MagicBell user id. Alternatively, provide an id like
+// email:theusersemail@example.com or external_id:theusersexternalid as the user id.
+func (s *UsersService) Update(ctx context.Context, userID string, req UpdateUserRequest, opts ...CallOption) (*User, error) {
+	var out updateUserResponse
+
+	if err := s.api.makeRequest(ctx, "UpdateUser", http.MethodPut, userEndpoint(userID), updateUserRequest{req}, &out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out.User, out.Err()
+}
+
+// Get retrieves the user in MagicBell with the given ID.
+// The user id is the MagicBell user id. Alternatively, provide an id like
+// email:theusersemail@example.com or external_id:theusersexternalid as the user id.
+// A NotFoundError is returned when there is no such user.
+func (s *UsersService) Get(ctx context.Context, userID string, opts ...CallOption) (*User, error) {
+	var out getUserResponse
+	op := &Operation{
+		Name:     "GetUser",
+		Method:   http.MethodGet,
+		Endpoint: userEndpoint(userID),
+		Header:   http.Header{},
+		Response: &out,
+	}
+
+	err := newCallOptions(opts).do(ctx, s.api, op)
+	if op.StatusCode == http.StatusNotFound {
+		// the body may not be JSON, the errors are only informative
+		return nil, NotFoundError{Errors: out.Errors}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return out.User, out.Err()
+}
+
+// Delete deletes the user in MagicBell with the given ID, along with their notifications.
+// The user id is the MagicBell user id. Alternatively, provide an id like
+// email:theusersemail@example.com or external_id:theusersexternalid as the user id.
+func (s *UsersService) Delete(ctx context.Context, userID string, opts ...CallOption) error {
+	var out baseResponse
+
+	if err := s.api.makeRequest(ctx, "DeleteUser", http.MethodDelete, userEndpoint(userID), nil, &out, opts...); err != nil {
+		return err
+	}
+
+	return out.Err()
+}
+
+// List returns a page of the users of the project.
+func (s *UsersService) List(ctx context.Context, req ListUsersRequest, opts ...CallOption) (*UserList, error) {
+	endpoint := "users"
+	if query := req.query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var out listUsersResponse
+
+	if err := s.api.makeRequest(ctx, "ListUsers", http.MethodGet, endpoint, nil, &out, opts...); err != nil {
+		return nil, err
+	}
+	if err := out.Err(); err != nil {
+		return nil, err
+	}
+
+	return &out.UserList, nil
+}
+
+// EmailUserID returns the user id to use instead of the MagicBell user id for the user with the given email.
+func EmailUserID(email string) string { return "email:" + email }
+
+// ExternalIDUserID returns the user id to use instead of the MagicBell user id for the user with the given external id.
+func ExternalIDUserID(externalID string) string { return "external_id:" + externalID }
+
+func userEndpoint(userID string) string {
+	return fmt.Sprintf("users/%s", url.PathEscape(userID))
+}
+
 // CreateUser creates a new user in MagicBell.
 // Please note that you must provide the user's email or the external id so MagicBell can uniquely identify the user.
 // The external id, if provided, must be unique to the user.
@@ -91,18 +238,17 @@ func CreateUser(req CreateUserRequest) (*User, error) { return api.CreateUser(re
 // Please note that you must provide the user's email or the external id so MagicBell can uniquely identify the user.
 // The external id, if provided, must be unique to the user.
 func (a *API) CreateUserC(ctx context.Context, req CreateUserRequest) (*User, error) {
-	var out createUserResponse
-
-	if err := a.makeRequest(ctx, http.MethodPost, "users", createUserRequest{req}, &out); err != nil {
-		return nil, err
-	}
-
-	return out.User, out.Err()
+	return a.services.Users.Create(ctx, req)
 }
 
-// CreateUserC is a global shortcut to API.CreateUserC
+// CreateUserC is a global shortcut to API.CreateUserC.
+// The project named on ctx with WithProject is used, if any.
 func CreateUserC(ctx context.Context, req CreateUserRequest) (*User, error) {
-	return api.CreateUserC(ctx, req)
+	projectAPI, err := apiFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return projectAPI.CreateUserC(ctx, req)
 }
 
 // UpdateUser updates a user in MagicBell with the given ID.
@@ -121,16 +267,15 @@ func UpdateUser(userID string, req UpdateUserRequest) (*User, error) {
 // The user id is the MagicBell user id. Alternatively, provide an id like
 // email:theusersemail@example.com or external_id:theusersexternalid as the user id.
 func (a *API) UpdateUserC(ctx context.Context, userID string, req UpdateUserRequest) (*User, error) {
-	var out updateUserResponse
-
-	if err := a.makeRequest(ctx, http.MethodPut, fmt.Sprintf("users/%s", userID), updateUserRequest{req}, &out); err != nil {
-		return nil, err
-	}
-
-	return out.User, out.Err()
+	return a.services.Users.Update(ctx, userID, req)
 }
 
-// UpdateUserC is a global shortcut to API.UpdateUserC
+// UpdateUserC is a global shortcut to API.UpdateUserC.
+// The project named on ctx with WithProject is used, if any.
 func UpdateUserC(ctx context.Context, userID string, req UpdateUserRequest) (*User, error) {
-	return api.UpdateUserC(ctx, userID, req)
+	projectAPI, err := apiFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return projectAPI.UpdateUserC(ctx, userID, req)
 }
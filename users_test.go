@@ -1,7 +1,9 @@
 package magicbell
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -203,3 +205,144 @@ func TestUpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUsersService_Get(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpStatus int
+		checkErr   func(*testing.T, error)
+		checkUser  func(*testing.T, *User)
+	}{
+		{
+			name:       "200",
+			httpStatus: http.StatusOK,
+			checkErr:   assertNoError,
+			checkUser: func(t *testing.T, user *User) {
+				require.NotNil(t, user)
+				assert.Equal(t, "7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c", user.ID)
+				assert.Equal(t, "hana@magicbell.io", user.Email)
+			},
+		},
+		{
+			name:       "404",
+			httpStatus: http.StatusNotFound,
+			checkErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				assert.True(t, IsNotFoundError(err))
+				assert.Equal(t, "HTTP 404 Not Found: Could not find the user", err.Error())
+			},
+			checkUser: func(t *testing.T, user *User) {
+				assert.Nil(t, user)
+			},
+		},
+		{
+			name:       "500",
+			httpStatus: http.StatusInternalServerError,
+			checkErr:   assertInternalServerError,
+			checkUser: func(t *testing.T, user *User) {
+				assert.Nil(t, user)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, "/users/7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c", http.MethodGet, test.httpStatus, func(config Config) {
+				client := NewClient(WithConfig(config))
+				user, err := client.Users.Get(context.Background(), "7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c")
+				test.checkErr(t, err)
+				test.checkUser(t, user)
+			})
+		})
+	}
+}
+
+func TestUsersService_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpStatus int
+		checkErr   func(*testing.T, error)
+	}{
+		{
+			name:       "204",
+			httpStatus: http.StatusNoContent,
+			checkErr:   assertNoError,
+		},
+		{
+			name:       "403",
+			httpStatus: http.StatusForbidden,
+			checkErr:   assertAPIError(APIErrorCodeForbidden, "You are not allowed to delete this user"),
+		},
+		{
+			name:       "500",
+			httpStatus: http.StatusInternalServerError,
+			checkErr:   assertInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runServer(t, "/users/7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c", http.MethodDelete, test.httpStatus, func(config Config) {
+				client := NewClient(WithConfig(config))
+				test.checkErr(t, client.Users.Delete(context.Background(), "7fb3ce9f-a866-4dff-8ce8-2f64f7c5ed4c"))
+			})
+		})
+	}
+}
+
+func TestUserIDs(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	require.NoError(t, client.Users.Delete(context.Background(), EmailUserID("hana@magicbell.io")))
+	require.NoError(t, client.Users.Delete(context.Background(), ExternalIDUserID("56780/a")))
+
+	assert.Equal(t, []string{"/users/email:hana@magicbell.io", "/users/external_id:56780%2Fa"}, paths)
+}
+
+func TestUsersService_List(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users", r.URL.Path)
+		assert.Equal(t, "page=1&per_page=2", r.URL.RawQuery)
+		http.ServeFile(w, r, "testdata/api/users_get_200.json")
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	list, err := client.Users.List(context.Background(), ListUsersRequest{Page: 1, PerPage: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, list.Total)
+	assert.Equal(t, 2, list.TotalPages)
+	require.Len(t, list.Users, 2)
+	assert.Equal(t, "56780", list.Users[0].ExternalID)
+	assert.Equal(t, CustomAttributes{"plan": "enterprise"}, list.Users[0].CustomAttributes)
+	assert.Equal(t, "joe@example.com", list.Users[1].Email)
+	assert.Empty(t, list.Users[1].ExternalID)
+}
+
+func TestNotFoundError(t *testing.T) {
+	assert.Equal(t, "HTTP 404 Not Found", NotFoundError{}.Error())
+	assert.False(t, IsNotFoundError(nil))
+	assert.False(t, IsNotFoundError(APIErrors{}))
+	assert.Equal(t, []string{"forbidden"}, errorCodes(NotFoundError{Errors: APIErrors{{Code: APIErrorCodeForbidden}}}))
+}
+
+func TestUsersService_Delete_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Could not find the user"}]}`))
+	}))
+	defer srv.Close()
+
+	// only Get returns a NotFoundError, the other operations return the errors of the response as before
+	client := NewClient(WithConfig(validConfig), WithBaseURL(srv.URL))
+	err := client.Users.Delete(context.Background(), EmailUserID("hana@magicbell.io"))
+	assert.True(t, IsAPIErrors(err))
+	assert.False(t, IsNotFoundError(err))
+}
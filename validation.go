@@ -0,0 +1,154 @@
+package magicbell
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// APIErrorCodeParamMissing is returned by client-side validation when a required field is not set
+	APIErrorCodeParamMissing APIErrorCode = "param_missing"
+	// APIErrorCodeParamTooLong is returned by client-side validation when a field exceeds its maximum length
+	APIErrorCodeParamTooLong APIErrorCode = "param_too_long"
+	// APIErrorCodeParamInvalid is returned by client-side validation when a field is not in the expected format
+	APIErrorCodeParamInvalid APIErrorCode = "param_invalid"
+	// APIErrorCodeParamDuplicate is returned by client-side validation when a field contains duplicate values
+	APIErrorCodeParamDuplicate APIErrorCode = "param_duplicate"
+
+	// maxNotificationTitleLength is the maximum number of characters MagicBell accepts for a notification title
+	maxNotificationTitleLength = 255
+)
+
+// FieldError represents a single client-side validation error for a request field.
+// Field uses the same dotted notation as the MagicBell API, for example notification.recipients[0].
+type FieldError struct {
+	Field   string       `json:"field"`
+	Code    APIErrorCode `json:"code"`
+	Message string       `json:"message"`
+}
+
+// Error returns the field name followed by the validation message
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// IsCode returns true when the provided APIErrorCode
+// matches the stored FieldError.Code
+func (e FieldError) IsCode(c APIErrorCode) bool {
+	return e.Code == c
+}
+
+// ValidationErrors is a slice of FieldError returned when a request
+// fails client-side validation, before any HTTP request is made.
+type ValidationErrors []FieldError
+
+// Error returns a string of the first FieldError
+func (e ValidationErrors) Error() string {
+	if len(e) > 0 {
+		return e[0].Error()
+	}
+	return ""
+}
+
+// IsValidationErrors returns true when err is not nil and
+// is an instance of ValidationErrors
+func IsValidationErrors(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(ValidationErrors)
+	return ok
+}
+
+func (e *ValidationErrors) add(field string, code APIErrorCode, format string, args ...interface{}) {
+	*e = append(*e, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate checks the CreateNotificationRequest for errors the MagicBell API would reject,
+// such as a missing or too long Title, recipients without an email or external id,
+// duplicate recipients, an unknown ContentFormat and a malformed ActionURL. The returned error, if any, is ValidationErrors.
+func (r CreateNotificationRequest) Validate() error {
+	var errs ValidationErrors
+
+	if strings.TrimSpace(r.Title) == "" {
+		errs.add("notification.title", APIErrorCodeParamMissing, "is missing")
+	} else if utf8.RuneCountInString(r.Title) > maxNotificationTitleLength {
+		errs.add("notification.title", APIErrorCodeParamTooLong, "must be at most %d characters", maxNotificationTitleLength)
+	}
+
+	if len(r.Recipients) == 0 {
+		errs.add("notification.recipients", APIErrorCodeParamMissing, "is missing")
+	}
+
+	seen := make(map[string]int, len(r.Recipients))
+	for i, recipient := range r.Recipients {
+		field := fmt.Sprintf("notification.recipients[%d]", i)
+
+		key := recipient.identity()
+		if key == "" {
+			errs.add(field, APIErrorCodeParamMissing, "must have an email or external id")
+			continue
+		}
+
+		if j, ok := seen[key]; ok {
+			errs.add(field, APIErrorCodeParamDuplicate, "is a duplicate of notification.recipients[%d]", j)
+			continue
+		}
+		seen[key] = i
+	}
+
+	if _, err := ParseContentFormat(string(r.ContentFormat)); err != nil {
+		errs.add("notification.content", APIErrorCodeParamInvalid, "has an unknown content format %q", r.ContentFormat)
+	}
+
+	if r.ActionURL != "" && !isValidURL(r.ActionURL) {
+		errs.add("notification.action_url", APIErrorCodeParamInvalid, "must be an absolute http or https URL")
+	}
+
+	return errs.err()
+}
+
+// Validate checks that the CreateUserRequest has an email or external id set
+// so MagicBell can uniquely identify the user. The returned error, if any, is ValidationErrors.
+func (r CreateUserRequest) Validate() error {
+	var errs ValidationErrors
+
+	if strings.TrimSpace(r.Email) == "" && strings.TrimSpace(r.ExternalID) == "" {
+		errs.add("user", APIErrorCodeParamMissing, "must have an email or external id")
+	}
+
+	return errs.err()
+}
+
+// identity returns a key that uniquely identifies the recipient,
+// preferring the email (case insensitive) over the external id.
+func (r NotificationRecipient) identity() string {
+	if email := strings.TrimSpace(r.Email); email != "" {
+		return "email:" + strings.ToLower(email)
+	}
+	if externalID := strings.TrimSpace(r.ExternalID); externalID != "" {
+		return "external_id:" + externalID
+	}
+	return ""
+}
+
+func isValidURL(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
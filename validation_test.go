@@ -0,0 +1,118 @@
+package magicbell
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNotificationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		modifyRequestFn func(*CreateNotificationRequest)
+		expectedErrs    ValidationErrors
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name: "missing title",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.Title = "  "
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.title", Code: APIErrorCodeParamMissing, Message: "is missing"},
+			},
+		},
+		{
+			name: "title too long",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.Title = strings.Repeat("a", 256)
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.title", Code: APIErrorCodeParamTooLong, Message: "must be at most 255 characters"},
+			},
+		},
+		{
+			name: "recipient without identity",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.Recipients = append(r.Recipients, NotificationRecipient{FirstName: "Hana"})
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.recipients[1]", Code: APIErrorCodeParamMissing, Message: "must have an email or external id"},
+			},
+		},
+		{
+			name: "duplicate recipients",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.Recipients = append(r.Recipients, NotificationRecipient{Email: "John@Example.com"})
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.recipients[1]", Code: APIErrorCodeParamDuplicate, Message: "is a duplicate of notification.recipients[0]"},
+			},
+		},
+		{
+			name: "invalid action url",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.ActionURL = "developer.magicbell.io/docs"
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.action_url", Code: APIErrorCodeParamInvalid, Message: "must be an absolute http or https URL"},
+			},
+		},
+		{
+			name: "unknown content format",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.ContentFormat = "rtf"
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.content", Code: APIErrorCodeParamInvalid, Message: `has an unknown content format "rtf"`},
+			},
+		},
+		{
+			name: "multiple errors",
+			modifyRequestFn: func(r *CreateNotificationRequest) {
+				r.Title = ""
+				r.Recipients = nil
+			},
+			expectedErrs: ValidationErrors{
+				{Field: "notification.title", Code: APIErrorCodeParamMissing, Message: "is missing"},
+				{Field: "notification.recipients", Code: APIErrorCodeParamMissing, Message: "is missing"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := initialCreateNotificationRequest
+			request.ActionURL = "https://developer.magicbell.io"
+			if test.modifyRequestFn != nil {
+				test.modifyRequestFn(&request)
+			}
+
+			err := request.Validate()
+			if test.expectedErrs == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, IsValidationErrors(err))
+			assert.Equal(t, test.expectedErrs, err)
+		})
+	}
+}
+
+func TestCreateUserRequest_Validate(t *testing.T) {
+	assert.NoError(t, CreateUserRequest{Email: "hana@magicbell.io"}.Validate())
+	assert.NoError(t, CreateUserRequest{ExternalID: "56780"}.Validate())
+
+	err := CreateUserRequest{FirstName: "Hana"}.Validate()
+	require.Error(t, err)
+	assert.Equal(t, ValidationErrors{
+		{Field: "user", Code: APIErrorCodeParamMissing, Message: "must have an email or external id"},
+	}, err)
+	assert.Equal(t, "user must have an email or external id", err.Error())
+}